@@ -0,0 +1,304 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Annotation kinds
+const (
+	AnnotationHLine   = "hline"   // Horizontal reference line at a value
+	AnnotationVLine   = "vline"   // Vertical reference line at a category
+	AnnotationHBand   = "hband"   // Shaded band between two values
+	AnnotationVBand   = "vband"   // Shaded band between two categories
+	AnnotationCallout = "callout" // Text with an arrow pointing at a data point
+)
+
+// Annotation is a reference line, shaded band or callout drawn over a line or bar chart
+type Annotation struct {
+	Kind   string  // One of the Annotation* kinds
+	From   float64 // Value of an hline, or the lower bound of an hband
+	To     float64 // Upper bound of an hband
+	At     string  // Category of a vline or callout, or the first category of a vband
+	Until  string  // Last category of a vband
+	Series string  // Series a callout points at, the first series when empty; unknown names draw nothing
+	Text   string
+	Color  string // Defaults to the axis color
+}
+
+// AddAnnotation adds a reference line, band or callout to the chart
+func (chart *BaseChart) AddAnnotation(annotation Annotation) *BaseChart {
+	chart.Annotations = append(chart.Annotations, annotation)
+	return chart
+}
+
+// AddHLine adds a labeled horizontal reference line at value, such as an SLO or target
+func (chart *BaseChart) AddHLine(value float64, text string) *BaseChart {
+	return chart.AddAnnotation(Annotation{Kind: AnnotationHLine, From: value, Text: text})
+}
+
+// AddVLine adds a labeled vertical reference line at a category label
+func (chart *BaseChart) AddVLine(category, text string) *BaseChart {
+	return chart.AddAnnotation(Annotation{Kind: AnnotationVLine, At: category, Text: text})
+}
+
+// AddHBand adds a labeled shaded band between two values
+func (chart *BaseChart) AddHBand(from, to float64, text string) *BaseChart {
+	return chart.AddAnnotation(Annotation{Kind: AnnotationHBand, From: math.Min(from, to), To: math.Max(from, to), Text: text})
+}
+
+// AddVBand adds a labeled shaded band spanning the categories from one label to another
+func (chart *BaseChart) AddVBand(from, until, text string) *BaseChart {
+	return chart.AddAnnotation(Annotation{Kind: AnnotationVBand, At: from, Until: until, Text: text})
+}
+
+// AddCallout adds text with an arrow pointing at the value of a series at a category
+// An empty series name points at the first series, or at the single series data
+func (chart *BaseChart) AddCallout(category, series, text string) *BaseChart {
+	return chart.AddAnnotation(Annotation{Kind: AnnotationCallout, At: category, Series: series, Text: text})
+}
+
+// plotArea maps categories and values to pixel positions in a line or bar chart
+type plotArea struct {
+	left, top, width, height int
+	maxValue                 float64
+	halfSlot                 float64                                     // Half the width of a category, zero when categories are points
+	x                        func(i int) float64                         // Center of category i
+	point                    func(series, i int) (x, y float64, ok bool) // Position of a data point
+}
+
+// y returns the vertical position of a value
+func (p plotArea) y(value float64) float64 {
+	return float64(p.top+p.height) - value/p.maxValue*float64(p.height)
+}
+
+// annotationMax returns the largest value referenced by a line or band, so the value scale can include it
+func (chart *BaseChart) annotationMax() float64 {
+	var maxValue float64
+	for _, a := range chart.Annotations {
+		switch a.Kind {
+		case AnnotationHLine:
+			maxValue = math.Max(maxValue, a.From)
+		case AnnotationHBand:
+			maxValue = math.Max(maxValue, a.To)
+		}
+	}
+	return maxValue
+}
+
+// categoryIndex returns the index of a category label, or -1
+func (chart *BaseChart) categoryIndex(label string) int {
+	for i, l := range chart.Labels {
+		if l == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// seriesIndex returns the index of a series by name, the first series when the name is empty,
+// or -1 when no series has the name
+func (chart *BaseChart) seriesIndex(name string) int {
+	if name == "" {
+		return 0
+	}
+	for i, series := range chart.Series {
+		if series.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// annotationColor returns the color of an annotation
+func (chart *BaseChart) annotationColor(a Annotation) string {
+	if a.Color != "" {
+		return a.Color
+	}
+	return chart.axisColor()
+}
+
+// renderAnnotationBands draws the shaded bands behind the data
+func (chart *BaseChart) renderAnnotationBands(svg *strings.Builder, plot plotArea) {
+	if plot.maxValue <= 0 {
+		return
+	}
+	for _, a := range chart.Annotations {
+		color := chart.annotationColor(a)
+		switch a.Kind {
+		case AnnotationHBand:
+			top := plot.y(math.Min(a.To, plot.maxValue))
+			bottom := plot.y(math.Max(a.From, 0))
+			if bottom <= top {
+				continue
+			}
+			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%.1f" width="%d" height="%.1f" fill="%s" fill-opacity="0.12"/>`,
+				plot.left, top, plot.width, bottom-top, color))
+			if a.Text != "" {
+				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" class="chart-tick"%s>%s</text>`,
+					plot.left+4, top+12, chart.textFill(), escapeText(a.Text)))
+			}
+		case AnnotationVBand:
+			from, until := chart.categoryIndex(a.At), chart.categoryIndex(a.Until)
+			if from < 0 || until < 0 {
+				continue
+			}
+			if until < from {
+				from, until = until, from
+			}
+			x0 := plot.x(from) - plot.halfSlot
+			x1 := plot.x(until) + plot.halfSlot
+			svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s" fill-opacity="0.12"/>`,
+				x0, plot.top, math.Max(1, x1-x0), plot.height, color))
+			if a.Text != "" {
+				svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" text-anchor="middle" class="chart-tick"%s>%s</text>`,
+					(x0+x1)/2, plot.top+12, chart.textFill(), escapeText(a.Text)))
+			}
+		}
+	}
+}
+
+// renderAnnotationMarks draws the reference lines and callouts over the data
+func (chart *BaseChart) renderAnnotationMarks(svg *strings.Builder, plot plotArea) {
+	if plot.maxValue <= 0 {
+		return
+	}
+	for _, a := range chart.Annotations {
+		color := chart.annotationColor(a)
+		switch a.Kind {
+		case AnnotationHLine:
+			if a.From < 0 || a.From > plot.maxValue {
+				continue
+			}
+			y := plot.y(a.From)
+			svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="%s" stroke-width="%g" stroke-dasharray="6,4"/>`,
+				plot.left, y, plot.left+plot.width, y, color, chart.axisWidth()/2))
+			if a.Text != "" {
+				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" class="chart-tick"%s>%s</text>`,
+					plot.left+plot.width-4, y-4, chart.textFill(), escapeText(a.Text)))
+			}
+		case AnnotationVLine:
+			i := chart.categoryIndex(a.At)
+			if i < 0 {
+				continue
+			}
+			x := plot.x(i)
+			svg.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="%g" stroke-dasharray="6,4"/>`,
+				x, plot.top, x, plot.top+plot.height, color, chart.axisWidth()/2))
+			if a.Text != "" {
+				svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" class="chart-tick"%s>%s</text>`,
+					x+4, plot.top+12, chart.textFill(), escapeText(a.Text)))
+			}
+		case AnnotationCallout:
+			i := chart.categoryIndex(a.At)
+			if i < 0 {
+				continue
+			}
+			series := chart.seriesIndex(a.Series)
+			if len(chart.Series) == 0 {
+				series = 0
+			}
+			if series < 0 {
+				continue // Callouts on unknown series are not drawn
+			}
+			px, py, ok := plot.point(series, i)
+			if !ok {
+				continue
+			}
+			chart.renderCallout(svg, plot, px, py, a.Text, color)
+		}
+	}
+}
+
+// renderCallout draws text above and beside a point with an arrow pointing at it
+// The text moves to the left or below the point when it would leave the plot area
+func (chart *BaseChart) renderCallout(svg *strings.Builder, plot plotArea, px, py float64, text, color string) {
+	width := chart.textWidth(text, "label")
+	tx, ty, anchor := px+30, py-30, "start"
+	if tx+width > float64(plot.left+plot.width) {
+		tx, anchor = px-30, "end"
+	}
+	if ty-12 < float64(plot.top) {
+		ty = py + 40
+	}
+
+	// The arrow runs from the text to just short of the point
+	sx, sy := tx, ty-4
+	if ty > py {
+		sy = ty - 14
+	}
+	dx, dy := px-sx, py-sy
+	length := math.Hypot(dx, dy)
+	ux, uy := dx/length, dy/length
+	ex, ey := px-ux*6, py-uy*6
+	svg.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="1"/>`,
+		sx, sy, ex, ey, color))
+	svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f L%.1f,%.1f L%.1f,%.1f Z" fill="%s"/>`,
+		ex, ey, ex-ux*6-uy*3, ey-uy*6+ux*3, ex-ux*6+uy*3, ey-uy*6-ux*3, color))
+	svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="%s" class="chart-label"%s>%s</text>`,
+		tx, ty, anchor, chart.textFill(), escapeText(text)))
+}
+
+// plotArea returns the geometry of a line chart's plot area
+func (c *LineChart) plotArea(chartWidth, chartHeight int, maxValue float64) plotArea {
+	count := c.categoryCount()
+	plot := plotArea{left: c.Margin.Left, top: c.Height - c.Margin.Bottom - chartHeight, width: chartWidth, height: chartHeight, maxValue: maxValue}
+	plot.x = func(i int) float64 {
+		if count <= 1 {
+			return float64(c.Margin.Left + chartWidth/2)
+		}
+		return float64(c.Margin.Left + i*chartWidth/(count-1))
+	}
+	plot.point = func(series, i int) (float64, float64, bool) {
+		data := c.Data
+		if len(c.Series) > 0 {
+			data = c.Series[series].Data
+		}
+		if i >= len(data) || IsMissing(data[i]) {
+			return 0, 0, false
+		}
+		return plot.x(i), plot.y(data[i]), true
+	}
+	return plot
+}
+
+// plotArea returns the geometry of a bar chart's plot area
+func (c *BarChart) plotArea(chartWidth, chartHeight int, maxValue float64) plotArea {
+	count := max(1, c.categoryCount())
+	slot := chartWidth / count
+	plot := plotArea{left: c.Margin.Left, top: c.Height - c.Margin.Bottom - chartHeight, width: chartWidth, height: chartHeight, maxValue: maxValue, halfSlot: float64(slot) / 2}
+	plot.x = func(i int) float64 {
+		return float64(c.Margin.Left + i*slot + slot/2)
+	}
+	plot.point = func(series, i int) (float64, float64, bool) {
+		if len(c.Series) == 0 {
+			if i >= len(c.Data) || IsMissing(c.Data[i]) {
+				return 0, 0, false
+			}
+			return plot.x(i), plot.y(c.Data[i]), true
+		}
+		if i >= len(c.Series[series].Data) || IsMissing(c.Series[series].Data[i]) {
+			return 0, 0, false
+		}
+		if c.stackMode() != StackGrouped {
+			// Point at the top of the series' segment in its stack
+			_, stacks := c.barStacks()
+			barWidth := slot / (len(stacks) + 1)
+			for k, stack := range stacks {
+				var total float64
+				for _, s := range stack {
+					total += c.segmentValue(stack, s, i)
+					if s == series {
+						return float64(c.Margin.Left + i*slot + k*barWidth + barWidth), plot.y(total), true
+					}
+				}
+			}
+		}
+		// Grouped bars sit side by side within the category
+		barWidth := slot / (len(c.Series) + 1)
+		x := c.Margin.Left + i*slot + series*barWidth + barWidth
+		return float64(x), plot.y(c.Series[series].Data[i]), true
+	}
+	return plot
+}
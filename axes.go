@@ -0,0 +1,133 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SetXAxisTitle sets the title drawn below the x-axis of line and bar charts
+func (chart *BaseChart) SetXAxisTitle(title string) *BaseChart {
+	chart.XAxisTitle = title
+	return chart
+}
+
+// SetYAxisTitle sets the title drawn rotated along the y-axis of line and bar charts
+// A y-axis title also adds value ticks to the y-axis
+func (chart *BaseChart) SetYAxisTitle(title string) *BaseChart {
+	chart.YAxisTitle = title
+	return chart
+}
+
+// SetXAxisUnit sets a unit suffix for the x-axis labels, such as "s" or " km"
+func (chart *BaseChart) SetXAxisUnit(unit string) *BaseChart {
+	chart.XAxisUnit = unit
+	return chart
+}
+
+// SetYAxisUnit sets a unit suffix for the y-axis ticks and bar value labels, such as "%" or " ms"
+// A y-axis unit also adds value ticks to the y-axis
+func (chart *BaseChart) SetYAxisUnit(unit string) *BaseChart {
+	chart.YAxisUnit = unit
+	return chart
+}
+
+// showYTicks reports whether value ticks are drawn along the y-axis
+func (chart *BaseChart) showYTicks() bool {
+	return chart.YAxisTitle != "" || chart.YAxisUnit != ""
+}
+
+// xAxisLabels returns the category labels with the x-axis unit appended
+func (chart *BaseChart) xAxisLabels() []string {
+	if chart.XAxisUnit == "" {
+		return chart.Labels
+	}
+	labels := make([]string, len(chart.Labels))
+	for i, label := range chart.Labels {
+		labels[i] = label + chart.XAxisUnit
+	}
+	return labels
+}
+
+// yTicks returns the value ticks between zero and maxValue with their labels
+func (chart *BaseChart) yTicks(maxValue float64) ([]float64, []string) {
+	if maxValue <= 0 {
+		return nil, nil
+	}
+	var ticks []float64
+	var labels []string
+	for _, tick := range niceTicks(0, maxValue, 5) {
+		if tick <= maxValue {
+			ticks = append(ticks, tick)
+			labels = append(labels, fmt.Sprintf("%g%s", tick, chart.YAxisUnit))
+		}
+	}
+	return ticks, labels
+}
+
+// axisTitleHeight is the space an axis title takes across its axis
+func (chart *BaseChart) axisTitleHeight() int {
+	return int(math.Ceil(chart.fontSize("label")*1.2)) + 4
+}
+
+// yTickWidth returns the space the y-axis tick labels need left of the axis
+func (chart *BaseChart) yTickWidth(maxValue float64) int {
+	_, labels := chart.yTicks(maxValue)
+	widest := 0.0
+	for _, label := range labels {
+		widest = math.Max(widest, chart.textWidth(label, "tick"))
+	}
+	return int(math.Ceil(widest)) + 8
+}
+
+// reserveAxisSpace grows the left margin so the y-axis ticks and title fit
+// The returned function restores the configured margin once rendering is done
+func (chart *BaseChart) reserveAxisSpace(maxValue float64) func() {
+	left := chart.Margin.Left
+	needed := 0
+	if chart.showYTicks() {
+		needed += chart.yTickWidth(maxValue)
+	}
+	if chart.YAxisTitle != "" {
+		needed += chart.axisTitleHeight() + 4
+	}
+	if needed > chart.Margin.Left {
+		chart.Margin.Left = needed
+	}
+	return func() { chart.Margin.Left = left }
+}
+
+// renderYTicks draws value ticks along the y-axis for a plot area chartHeight pixels tall
+func (chart *BaseChart) renderYTicks(svg *strings.Builder, maxValue float64, chartHeight int) {
+	if !chart.showYTicks() {
+		return
+	}
+	ticks, labels := chart.yTicks(maxValue)
+	for i, tick := range ticks {
+		y := chart.Height - chart.Margin.Bottom - int(tick/maxValue*float64(chartHeight))
+		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+			chart.Margin.Left-5, y, chart.Margin.Left, y, chart.axisColor(), chart.gridWidth()))
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="end" class="chart-tick"%s>%s</text>`,
+			chart.Margin.Left-8, y+4, chart.textFill(), escapeText(labels[i])))
+	}
+}
+
+// renderAxisTitles draws the x-axis title below the x-axis labels and the y-axis title
+// rotated left of the y-axis ticks
+func (chart *BaseChart) renderAxisTitles(svg *strings.Builder, xLabels xLabelLayout, maxValue float64, chartWidth, chartHeight int) {
+	if chart.XAxisTitle != "" {
+		x := chart.Margin.Left + chartWidth/2
+		y := chart.Height - chart.Margin.Bottom + xLabels.height + chart.axisTitleHeight() - 4
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label"%s>%s</text>`,
+			x, y, chart.textFill(), escapeText(chart.XAxisTitle)))
+	}
+	if chart.YAxisTitle != "" {
+		x := chart.Margin.Left - 4
+		if chart.showYTicks() {
+			x -= chart.yTickWidth(maxValue)
+		}
+		y := chart.Margin.Top + chartHeight/2
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" transform="rotate(-90 %d %d)" class="chart-label"%s>%s</text>`,
+			x, y, x, y, chart.textFill(), escapeText(chart.YAxisTitle)))
+	}
+}
@@ -2,6 +2,7 @@ package gosvgchart
 
 import (
 	"fmt"
+	"html"
 	"math"
 	"sort"
 	"strings"
@@ -41,9 +42,44 @@ type BaseChart struct {
 	SeriesColors []string
 	ShowTitle    bool
 	ShowLegend   bool
-	LegendWidth  float64 // Percentage of chart width (0.0-0.5) reserved for legend
-	Palette      string  // "auto" or "gradient" for automatic color assignment
-	Margin       struct {
+	LegendWidth  float64      // Percentage of chart width (0.0-0.5) reserved for legend
+	Palette      string       // "auto", "gradient" or a named palette such as "okabe-ito" for automatic color assignment
+	Patterns     bool         // Fill bars and slices with patterns as well as colors, for grayscale printing
+	Typography   Typography   // Font family and sizes, zero values keep the defaults
+	Strokes      StrokeWidths // Line, axis and grid stroke widths, zero values keep the defaults
+	// X-axis label handling for line and bar charts
+	XLabelStrategy string // "auto" (default), "horizontal", "wrap", "rotate45", "rotate90" or "skip"
+	XLabelStep     int    // Draw every Nth x-axis label, zero chooses automatically
+	// Axis titles and unit suffixes for line and bar charts
+	XAxisTitle string
+	YAxisTitle string
+	XAxisUnit  string // Appended to x-axis labels
+	YAxisUnit  string // Appended to y-axis ticks and bar values
+	// Reference lines, bands and callouts for line and bar charts
+	Annotations []Annotation
+	// Trendlines and moving averages for line and bar charts
+	Overlays     []Overlay
+	ShowFitStats bool // Show fit parameters of trendlines in the legend
+	// Error bounds of the single series data
+	DataLower []float64
+	DataUpper []float64
+	// Dash pattern, stroke width, opacity and markers of the single series data
+	DataStyle SeriesStyle
+	// Style overrides of single points of the single series data, and conditional coloring rules
+	DataPoints map[int]PointStyle
+	Highlights []HighlightRule
+	// Links of the single series data and its points, the target of all links,
+	// and whether marks carry data-series, data-label and data-value attributes
+	DataURL        string
+	DataURLs       map[int]string
+	LinkTarget     string
+	DataAttributes bool
+	// Tooltip template of every mark, whether hovering a mark dims the others,
+	// and whether line and bar charts embed the interaction script
+	Tooltip        string
+	HoverHighlight bool
+	Interactive    bool
+	Margin         struct {
 		Top    int
 		Right  int
 		Bottom int
@@ -67,45 +103,79 @@ type BaseChart struct {
 
 // Series represents a data series with a name and values
 type Series struct {
-	Name string
-	Data []float64
+	Name  string
+	Data  []float64
+	Lower []float64   // Optional lower bound of each value, for error bars and confidence bands
+	Upper []float64   // Optional upper bound of each value
+	Style SeriesStyle // Optional dash pattern, stroke width, opacity and markers of a line series
+	// Style overrides of single points by index
+	Points map[int]PointStyle
+	// Link of every mark of the series, and links of single points by index
+	URL  string
+	URLs map[int]string
 }
 
 // LineChart implements a line chart
 type LineChart struct {
 	BaseChart
-	ShowPoints bool
-	Smooth     bool
+	ShowPoints    bool
+	Smooth        bool    // Draw monotone cubic lines when no interpolation is set
+	FillArea      bool    // Fill the area under each line
+	GapMode       string  // How lines handle missing values: "break" (default), "connect" or "interpolate"
+	Interpolation string  // "linear" (default), "monotone", "catmull-rom", "step-before", "step-after" or "step-middle"
+	Tension       float64 // Tension of Catmull-Rom lines, 0 for the standard spline and 1 for straight lines
+	Downsample    string  // "lttb", "minmax" or "average" to draw about one point per pixel column
+	MarkerLimit   int     // Series with more points are drawn without point markers, 0 for no limit
 }
 
 // BarChart implements a bar chart
 type BarChart struct {
 	BaseChart
-	Horizontal bool
-	Stacked    bool
+	Horizontal  bool
+	Stacked     bool
+	StackMode   string            // "grouped", "stacked", "percent" or "grouped-stacked", overrides Stacked when set
+	StackGroups map[string]string // Stack group of each series by name in the grouped-stacked mode
 }
 
 // PieChart implements a pie/donut chart
 type PieChart struct {
 	BaseChart
 	DonutHolePercentage float64
-	MaxLabelLength      int  // Maximum label length before truncation
-	ShowTooltips        bool // Show tooltips on hover for truncated labels
+	MaxLabelLength      int      // Maximum label length before truncation
+	ShowTooltips        bool     // Show tooltips on hover for truncated labels
+	LabelMode           string   // Where slice labels go: "inside" (default) or "outside"
+	OtherThreshold      float64  // Slices below this percentage of the total are grouped into "Other"
+	OtherTopN           int      // Only the largest n slices are kept, the rest grouped into "Other"
+	OtherLabel          string   // Label of the grouped slice, "Other" when empty
+	SortSlices          string   // Slice order: "" (data order), "desc" or "asc"
+	StartAngle          float64  // Angle where the first slice starts, in degrees clockwise from 3 o'clock
+	Exploded            []string // Labels of slices pulled out of the pie
 }
 
 // HeatmapChart implements a heatmap chart similar to GitHub's activity heatmap
 type HeatmapChart struct {
 	BaseChart
-	CellSize       int      // Size of each cell in pixels
-	CellSpacing    int      // Spacing between cells in pixels
-	CellRounding   int      // Corner radius of cells
-	DateFormat     string   // Date format string
-	DayLabels      []string // Labels for days of week (Sunday-Saturday)
-	MonthLabels    []string // Labels for months
-	MaxValue       float64  // Maximum value for color scaling (0 for auto)
-	MinValue       float64  // Minimum value for color scaling (0 for auto)
-	NegativeColors []string // Colors for negative values (from least to most intense)
-	SupportNegative bool    // Whether to support negative values
+	CellSize        int          // Size of each cell in pixels
+	CellSpacing     int          // Spacing between cells in pixels
+	CellRounding    int          // Corner radius of cells
+	DateFormat      string       // Date format string
+	DayLabels       []string     // Labels for days of week (Sunday-Saturday)
+	MonthLabels     []string     // Labels for months
+	MaxValue        float64      // Maximum value for color scaling (0 for auto)
+	MinValue        float64      // Minimum value for color scaling (0 for auto)
+	NegativeColors  []string     // Colors for negative values (from least to most intense)
+	SupportNegative bool         // Whether to support negative values
+	Matrix          [][]float64  // Value grid for matrix heatmaps (rows of columns), replaces the calendar layout
+	RowLabels       []string     // Labels for matrix rows
+	ColumnLabels    []string     // Labels for matrix columns
+	ShowValues      bool         // Show the value inside each matrix cell
+	ValueFormat     string       // Format string for cell values (fmt verb)
+	ColorScale      *ColorScale  // Continuous color scale, replaces the Colors buckets when set
+	WeekStart       time.Weekday // First day of each calendar column (Sunday or Monday for ISO weeks)
+	Aggregation     string       // How values sharing a day are combined: "last", "sum", "mean", "max" or "min"
+	SplitYears      bool         // Draw one calendar row per year when the data spans several years
+	TooltipFormat   string       // Date layout used in cell tooltips
+	EmptyColor      string       // Fill of calendar days without data, outside the color scale
 }
 
 // New creates a new line chart (for backward compatibility)
@@ -224,17 +294,23 @@ func NewHeatmapChart() *HeatmapChart {
 			BackgroundColor: "#ffffff",
 			DarkModeSupport: true, // Enable dark mode by default
 		},
-		CellSize:       15,
-		CellSpacing:    3,
-		CellRounding:   2,
-		DateFormat:     "2006-01-02",
+		CellSize:     15,
+		CellSpacing:  3,
+		CellRounding: 2,
+		DateFormat:   "2006-01-02",
 		// We'll use the single-letter day labels defined in the Render method
 		// so we don't need to set them here anymore
-		DayLabels:      []string{},
-		MonthLabels:    []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
-		MaxValue:       0, // 0 means auto-scale
-		MinValue:       0, // 0 means auto-scale
+		DayLabels:       []string{},
+		MonthLabels:     []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		MaxValue:        0,    // 0 means auto-scale
+		MinValue:        0,    // 0 means auto-scale
 		SupportNegative: true, // Enable negative values support by default
+		ValueFormat:     "%g",
+		WeekStart:       time.Sunday,
+		Aggregation:     "last",
+		SplitYears:      true,
+		TooltipFormat:   "Mon, Jan 2, 2006",
+		EmptyColor:      "#ebedf0",
 	}
 
 	chart.Margin.Top = 50
@@ -244,7 +320,7 @@ func NewHeatmapChart() *HeatmapChart {
 
 	// Default colors - from light to dark for intensity (positive values)
 	chart.Colors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
-	
+
 	// Default colors for negative values - from light to dark intensity
 	chart.NegativeColors = []string{"#ebedf0", "#f9a8a8", "#f67575", "#e64545", "#c92a2a"}
 
@@ -551,13 +627,101 @@ func (c *HeatmapChart) SetMonthLabels(labels []string) *HeatmapChart {
 	return c
 }
 
+// SetMatrix switches the heatmap to a matrix layout with the given row and column labels
+// Values are indexed as values[row][column]
+func (c *HeatmapChart) SetMatrix(rowLabels, columnLabels []string, values [][]float64) *HeatmapChart {
+	c.RowLabels = rowLabels
+	c.ColumnLabels = columnLabels
+	c.Matrix = values
+	return c
+}
+
+// SetShowValues shows or hides the value text inside matrix cells
+func (c *HeatmapChart) SetShowValues(show bool) *HeatmapChart {
+	c.ShowValues = show
+	return c
+}
+
+// SetValueFormat sets the format string for matrix cell values (e.g. "%.1f")
+func (c *HeatmapChart) SetValueFormat(format string) *HeatmapChart {
+	c.ValueFormat = format
+	return c
+}
+
+// SetColorScale selects a built-in continuous color scale such as "viridis", "magma", "cividis" or "rdbu"
+// Unknown names are ignored
+func (c *HeatmapChart) SetColorScale(name string) *HeatmapChart {
+	if scale, ok := NamedColorScale(name); ok {
+		c.ColorScale = &scale
+	}
+	return c
+}
+
+// SetCustomColorScale sets a continuous color scale from evenly spaced hex color stops
+// The scale is ignored unless it has at least two stops and every stop is a hex color,
+// see ColorScale.Validate
+func (c *HeatmapChart) SetCustomColorScale(stops []string) *HeatmapChart {
+	scale := NewColorScale(stops...)
+	if scale.Validate() == nil {
+		c.ColorScale = &scale
+	}
+	return c
+}
+
+// SetWeekStart sets the first day of each calendar column
+// Use time.Monday for ISO 8601 weeks
+func (c *HeatmapChart) SetWeekStart(day time.Weekday) *HeatmapChart {
+	if day >= time.Sunday && day <= time.Saturday {
+		c.WeekStart = day
+	}
+	return c
+}
+
+// SetAggregation sets how values that fall on the same day are combined
+// Supported methods are "last" (default, the last value wins), "sum", "mean", "max" and "min"
+func (c *HeatmapChart) SetAggregation(method string) *HeatmapChart {
+	method = strings.ToLower(strings.TrimSpace(method))
+	switch method {
+	case "sum", "mean", "max", "min", "last":
+		c.Aggregation = method
+	case "avg", "average":
+		c.Aggregation = "mean"
+	}
+	return c
+}
+
+// SetSplitYears draws one calendar row per year when the data spans several years
+func (c *HeatmapChart) SetSplitYears(split bool) *HeatmapChart {
+	c.SplitYears = split
+	return c
+}
+
+// SetEmptyColor sets the fill of calendar days without data, which keeps them apart from
+// days whose value is the minimum of the color scale
+func (c *HeatmapChart) SetEmptyColor(color string) *HeatmapChart {
+	c.EmptyColor = color
+	return c
+}
+
+// SetTooltipFormat sets the date layout used in cell tooltips
+func (c *HeatmapChart) SetTooltipFormat(format string) *HeatmapChart {
+	c.TooltipFormat = format
+	return c
+}
+
 // ShowDataPoints shows or hides data points for line charts
 func (c *LineChart) ShowDataPoints(show bool) *LineChart {
 	c.ShowPoints = show
 	return c
 }
 
-// SetSmooth enables smooth curved lines
+// SetFillArea fills the area under each line with a translucent series color
+func (c *LineChart) SetFillArea(fill bool) *LineChart {
+	c.FillArea = fill
+	return c
+}
+
+// SetSmooth enables smooth curved lines, drawn as monotone cubic curves unless an interpolation is set
 func (c *LineChart) SetSmooth(smooth bool) *LineChart {
 	c.Smooth = smooth
 	return c
@@ -602,6 +766,7 @@ func (c *PieChart) EnableTooltips(enable bool) *PieChart {
 // Render renders the line chart to an SVG string
 func (c *LineChart) Render() string {
 	var svg strings.Builder
+	patterns := c.newPatternFills()
 
 	// Apply auto-height if enabled
 	if c.AutoHeight {
@@ -609,55 +774,11 @@ func (c *LineChart) Render() string {
 		c.Height = c.Width * 9 / 16
 	}
 
-	// Start SVG with namespace
-	svg.WriteString(fmt.Sprintf(`<svg width="100%%" height="auto" viewBox="0 0 %d %d" preserveAspectRatio="xMidYMid meet" xmlns="http://www.w3.org/2000/svg">`, c.Width, c.Height))
-
-	// Add dark/light mode support if enabled
-	if c.DarkModeSupport {
-		svg.WriteString(fmt.Sprintf(`
-			<style>
-				:root {
-					--chart-bg: %s;
-					--chart-text: %s;
-					--chart-axis: %s;
-					--chart-grid: %s;
-				}
-				@media (prefers-color-scheme: dark) {
-					:root {
-						--chart-bg: %s;
-						--chart-text: %s;
-						--chart-axis: %s;
-						--chart-grid: %s;
-					}
-				}
-			</style>
-		`,
-			c.LightTheme.BackgroundColor,
-			c.LightTheme.TextColor,
-			c.LightTheme.AxisColor,
-			c.LightTheme.GridColor,
-			c.DarkTheme.BackgroundColor,
-			c.DarkTheme.TextColor,
-			c.DarkTheme.AxisColor,
-			c.DarkTheme.GridColor))
-
-		// Background with CSS variables
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="var(--chart-bg)"/>`, c.Width, c.Height))
-	} else {
-		// Background with static color
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, c.Width, c.Height, c.BackgroundColor))
-	}
+	// Start SVG with header, styles and background
+	c.renderHeader(&svg)
 
 	// Title
-	if c.ShowTitle && c.Title != "" {
-		if c.DarkModeSupport {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold" fill="var(--chart-text)">%s</text>`,
-				c.Width/2, c.Title))
-		} else {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold">%s</text>`,
-				c.Width/2, c.Title))
-		}
-	}
+	c.renderTitle(&svg)
 
 	// Calculate the legend area width in pixels (if legend is shown)
 	var legendAreaWidth int
@@ -665,12 +786,8 @@ func (c *LineChart) Render() string {
 		legendAreaWidth = int(float64(c.Width) * c.LegendWidth)
 	}
 
-	// Chart area dimensions (reduced by legend width if showing legend)
-	chartWidth := c.Width - c.Margin.Left - c.Margin.Right - legendAreaWidth
-	chartHeight := c.Height - c.Margin.Top - c.Margin.Bottom
-
 	// Adjust legendX calculation for later use based on the reserved area
-	legendX := c.Width - c.Margin.Right - 150
+	legendX := c.Width - c.Margin.Right - c.legendColumnWidth()
 	if c.ShowLegend && c.LegendWidth > 0 && len(c.Series) > 0 {
 		legendX = c.Width - legendAreaWidth + 20
 	}
@@ -699,21 +816,34 @@ func (c *LineChart) Render() string {
 		}
 	}
 
+	// Include error bounds, reference lines, bands, trendlines and moving averages in the scale
+	overlays := c.computeOverlays()
+	maxValue = math.Max(math.Max(maxValue, c.boundsMax()), math.Max(c.annotationMax(), overlayMax(overlays)))
+
 	// Add 10% padding to the max value
 	maxValue *= 1.1
 
+	// Make room for the y-axis ticks and title, and for the first and last x-axis labels
+	defer c.reserveAxisSpace(maxValue)()
+	defer c.reserveEdgeLabelSpace(legendAreaWidth > 0)()
+
+	// Chart area dimensions (reduced by legend width if showing legend)
+	chartWidth := c.Width - c.Margin.Left - c.Margin.Right - legendAreaWidth
+
+	// Lay out the x-axis labels, wrapped or rotated labels and the x-axis title need a taller bottom margin
+	xLabels := c.layoutXLabels(float64(chartWidth) / math.Max(1, float64(c.categoryCount()-1)))
+	defer c.reserveXLabelSpace(xLabels)()
+	chartHeight := c.Height - c.Margin.Top - c.Margin.Bottom
+
 	// Draw axes with theme support
-	if c.DarkModeSupport {
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="var(--chart-axis)" stroke-width="2"/>`,
-			c.Margin.Left, c.Height-c.Margin.Bottom, c.Width-c.Margin.Right, c.Height-c.Margin.Bottom))
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="var(--chart-axis)" stroke-width="2"/>`,
-			c.Margin.Left, c.Margin.Top, c.Margin.Left, c.Height-c.Margin.Bottom))
-	} else {
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black" stroke-width="2"/>`,
-			c.Margin.Left, c.Height-c.Margin.Bottom, c.Width-c.Margin.Right, c.Height-c.Margin.Bottom))
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black" stroke-width="2"/>`,
-			c.Margin.Left, c.Margin.Top, c.Margin.Left, c.Height-c.Margin.Bottom))
-	}
+	svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+		c.Margin.Left, c.Height-c.Margin.Bottom, c.Width-c.Margin.Right, c.Height-c.Margin.Bottom, c.axisColor(), c.axisWidth()))
+	svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+		c.Margin.Left, c.Margin.Top, c.Margin.Left, c.Height-c.Margin.Bottom, c.axisColor(), c.axisWidth()))
+
+	c.renderYTicks(&svg, maxValue, chartHeight)
+	plot := c.plotArea(chartWidth, chartHeight, maxValue)
+	c.renderAnnotationBands(&svg, plot)
 
 	// Draw data
 	if hasMultipleSeries {
@@ -723,17 +853,6 @@ func (c *LineChart) Render() string {
 				continue
 			}
 
-			// Calculate point coordinates
-			points := make([][2]int, len(series.Data))
-			for i, v := range series.Data {
-				x := c.Margin.Left + i*chartWidth/(len(series.Data)-1)
-				if len(series.Data) == 1 {
-					x = c.Margin.Left + chartWidth/2
-				}
-				y := c.Height - c.Margin.Bottom - int(v/maxValue*float64(chartHeight))
-				points[i] = [2]int{x, y}
-			}
-
 			// Determine color for this series
 			var color string
 			if seriesIndex < len(c.SeriesColors) {
@@ -742,36 +861,16 @@ func (c *LineChart) Render() string {
 				color = c.Colors[seriesIndex%len(c.Colors)]
 			} else {
 				// Default colors if none specified
-				defaultColors := []string{"#4285F4", "#EA4335", "#FBBC05", "#34A853", "#8AB4F8", "#F6AEA9", "#FDE293", "#A8DAB5"}
-				color = defaultColors[seriesIndex%len(defaultColors)]
-			}
-
-			// Draw line
-			var path strings.Builder
-			path.WriteString(fmt.Sprintf(`<path d="M%d,%d`, points[0][0], points[0][1]))
-			for i := 1; i < len(points); i++ {
-				if c.Smooth && i < len(points)-1 {
-					// Calculate control points for smooth curve
-					x1 := points[i-1][0]
-					y1 := points[i-1][1]
-					x2 := points[i][0]
-					y2 := points[i][1]
-					xc := (x1 + x2) / 2
-					path.WriteString(fmt.Sprintf(" Q%d,%d %d,%d", xc, y1, xc, (y1+y2)/2))
-					path.WriteString(fmt.Sprintf(" Q%d,%d %d,%d", xc, y2, x2, y2))
-				} else {
-					path.WriteString(fmt.Sprintf(" L%d,%d", points[i][0], points[i][1]))
-				}
+				color = autoPalette[seriesIndex%len(autoPalette)]
 			}
-			path.WriteString(`" fill="none" stroke="` + color + `" stroke-width="3"/>`)
-			svg.WriteString(path.String())
+			c.renderConfidenceBand(&svg, plot, seriesIndex, color)
 
-			// Draw points if enabled
-			if c.ShowPoints {
-				for _, p := range points {
-					svg.WriteString(fmt.Sprintf(`<circle cx="%d" cy="%d" r="5" fill="%s"/>`, p[0], p[1], color))
-				}
+			// Draw line, broken at missing values, and points if enabled
+			areaFill := ""
+			if c.FillArea {
+				areaFill = patterns.fill(seriesIndex, color)
 			}
+			c.renderLineSeries(&svg, seriesIndex, series.Data, color, areaFill, c.seriesStyle(seriesIndex), chartWidth, chartHeight, maxValue)
 		}
 
 		// Draw legend if we have multiple series
@@ -788,22 +887,23 @@ func (c *LineChart) Render() string {
 					color = c.Colors[i%len(c.Colors)]
 				} else {
 					// Default colors if none specified
-					defaultColors := []string{"#4285F4", "#EA4335", "#FBBC05", "#34A853", "#8AB4F8", "#F6AEA9", "#FDE293", "#A8DAB5"}
-					color = defaultColors[i%len(defaultColors)]
+					color = autoPalette[i%len(autoPalette)]
 				}
 
-				// Draw legend item
-				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="15" height="15" fill="%s"/>`,
-					legendX, legendY+i*25, color))
-
-				if c.DarkModeSupport {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-						legendX+25, legendY+i*25+12, series.Name))
+				// Draw legend item, showing the area pattern when areas are filled
+				// and the line style and marker otherwise
+				c.beginLegendItem(&svg, series.Name)
+				if c.FillArea {
+					svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="15" height="15" fill="%s"/>`,
+						legendX, legendY+i*25, patterns.fill(i, color)))
 				} else {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12">%s</text>`,
-						legendX+25, legendY+i*25+12, series.Name))
+					c.renderLineLegendKey(&svg, legendX, legendY+i*25, c.seriesStyle(i), color)
 				}
+
+				c.renderLegendText(&svg, legendX+25, legendY+i*25+12, series.Name, float64(c.Width-legendX-30))
+				c.endLegendItem(&svg)
 			}
+			c.renderOverlayLegend(&svg, legendX, legendY+len(c.Series)*25, overlays)
 		}
 
 		// Draw labels on x-axis if available
@@ -816,84 +916,127 @@ func (c *LineChart) Render() string {
 				}
 			}
 
-			for i := 0; i < numPoints && i < len(c.Labels); i++ {
-				x := c.Margin.Left + i*chartWidth/(numPoints-1)
-				if numPoints == 1 {
-					x = c.Margin.Left + chartWidth/2
-				}
-
-				if c.DarkModeSupport {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-						x, c.Height-c.Margin.Bottom+20, c.Labels[i]))
-				} else {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12">%s</text>`,
-						x, c.Height-c.Margin.Bottom+20, c.Labels[i]))
+			positions := make([]int, numPoints)
+			for i := range positions {
+				positions[i] = c.Margin.Left + chartWidth/2
+				if numPoints > 1 {
+					positions[i] = c.Margin.Left + i*chartWidth/(numPoints-1)
 				}
 			}
+			c.renderXLabels(&svg, positions, xLabels)
 		}
 	} else if len(c.Data) > 0 {
 		// Legacy single series support
-		// Calculate point coordinates
-		points := make([][2]int, len(c.Data))
-		for i, v := range c.Data {
-			x := c.Margin.Left + i*chartWidth/(len(c.Data)-1)
-			if len(c.Data) == 1 {
-				x = c.Margin.Left + chartWidth/2
-			}
-			y := c.Height - c.Margin.Bottom - int(v/maxValue*float64(chartHeight))
-			points[i] = [2]int{x, y}
-		}
-
-		// Draw line
-		var path strings.Builder
-		path.WriteString(fmt.Sprintf(`<path d="M%d,%d`, points[0][0], points[0][1]))
-		for i := 1; i < len(points); i++ {
-			if c.Smooth && i < len(points)-1 {
-				// Calculate control points for smooth curve
-				x1 := points[i-1][0]
-				y1 := points[i-1][1]
-				x2 := points[i][0]
-				y2 := points[i][1]
-				xc := (x1 + x2) / 2
-				path.WriteString(fmt.Sprintf(" Q%d,%d %d,%d", xc, y1, xc, (y1+y2)/2))
-				path.WriteString(fmt.Sprintf(" Q%d,%d %d,%d", xc, y2, x2, y2))
-			} else {
-				path.WriteString(fmt.Sprintf(" L%d,%d", points[i][0], points[i][1]))
-			}
-		}
-		path.WriteString(`" fill="none" stroke="` + c.Colors[0] + `" stroke-width="3"/>`)
-		svg.WriteString(path.String())
+		c.renderConfidenceBand(&svg, plot, 0, c.Colors[0])
 
-		// Draw points if enabled
-		if c.ShowPoints {
-			for _, p := range points {
-				svg.WriteString(fmt.Sprintf(`<circle cx="%d" cy="%d" r="5" fill="%s"/>`, p[0], p[1], c.Colors[0]))
-			}
+		// Draw line, broken at missing values, and points if enabled
+		areaFill := ""
+		if c.FillArea {
+			areaFill = patterns.fill(0, c.Colors[0])
 		}
+		c.renderLineSeries(&svg, 0, c.Data, c.Colors[0], areaFill, c.seriesStyle(0), chartWidth, chartHeight, maxValue)
 
 		// Draw labels if available
 		if len(c.Labels) > 0 {
-			for i, p := range points {
-				if i < len(c.Labels) {
-					if c.DarkModeSupport {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-							p[0], c.Height-c.Margin.Bottom+20, c.Labels[i]))
-					} else {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12">%s</text>`,
-							p[0], c.Height-c.Margin.Bottom+20, c.Labels[i]))
-					}
+			positions := make([]int, len(c.Data))
+			for i := range positions {
+				positions[i] = c.Margin.Left + chartWidth/2
+				if len(c.Data) > 1 {
+					positions[i] = c.Margin.Left + i*chartWidth/(len(c.Data)-1)
 				}
 			}
+			c.renderXLabels(&svg, positions, xLabels)
 		}
 	}
 
+	c.renderOverlays(&svg, plot, overlays, !c.ShowLegend || len(c.Series) == 0)
+	c.renderAnnotationMarks(&svg, plot)
+	c.renderAxisTitles(&svg, xLabels, maxValue, chartWidth, chartHeight)
+	c.renderScript(&svg, plot, true)
+
+	patterns.writeDefs(&svg)
 	svg.WriteString("</svg>")
 	return svg.String()
 }
 
+// renderLineSeries draws one series as a line with an optional area fill below it and its points
+// The line breaks at missing values unless the gap mode connects or interpolates across them,
+// and missing values get no point
+// With downsampling each run of the line keeps about one point per pixel column, and series
+// with more points than the marker limit are drawn without point markers, except highlighted points
+func (c *LineChart) renderLineSeries(svg *strings.Builder, series int, data []float64, color, areaFill string, style SeriesStyle, chartWidth, chartHeight int, maxValue float64) {
+	values, segments := gapSegments(data, c.GapMode)
+	pixelsPerIndex := float64(chartWidth) / math.Max(1, float64(len(values)-1))
+
+	// Calculate point coordinates
+	position := func(p samplePoint) [2]float64 {
+		x := float64(c.Margin.Left + chartWidth/2)
+		if len(values) > 1 {
+			x = float64(c.Margin.Left) + p.index*pixelsPerIndex
+		}
+		return [2]float64{x, float64(c.Height-c.Margin.Bottom) - p.value/maxValue*float64(chartHeight)}
+	}
+
+	var markers []int
+	positions := make(map[int][2]float64)
+	for _, segment := range segments {
+		run := make([]samplePoint, len(segment))
+		for k, i := range segment {
+			run[k] = samplePoint{float64(i), values[i]}
+		}
+		if c.Downsample != DownsampleNone {
+			run = downsample(c.Downsample, run, pixelsPerIndex)
+		}
+		points := make([][2]float64, len(run))
+		for k, p := range run {
+			points[k] = position(p)
+
+			// Only actual data values get a marker, not interpolated or averaged ones
+			if i := int(p.index); float64(i) == p.index && data[i] == p.value {
+				markers = append(markers, i)
+				positions[i] = points[k]
+			}
+		}
+
+		// The area fill follows the same curve as the line down to the baseline
+		path := linePath(points, c.interpolation(), c.Tension)
+		if areaFill != "" {
+			baseline := float64(c.Height - c.Margin.Bottom)
+			svg.WriteString(fmt.Sprintf(`<path d="%s L%.1f,%.1f L%.1f,%.1f Z" fill="%s" fill-opacity="0.3" stroke="none"%s/>`,
+				path, points[len(points)-1][0], baseline, points[0][0], baseline, areaFill, c.dataAttributes(c.markFields(series, -1))))
+		}
+		attrs := c.beginMark(svg, series, -1)
+		svg.WriteString(fmt.Sprintf(`<path d="%s" fill="none" stroke="%s" stroke-width="%g"%s%s/>`,
+			path, color, style.Width, style.strokeAttributes(), attrs))
+		c.endMark(svg, series, -1)
+	}
+
+	showPoints := c.ShowPoints && (c.MarkerLimit <= 0 || len(markers) <= c.MarkerLimit)
+	for _, i := range markers {
+		p := positions[i]
+		point, highlighted := c.pointStyle(series, i)
+		if !highlighted && !showPoints {
+			// Interactive charts keep an invisible point so the crosshair can read its value
+			if c.Interactive {
+				svg.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="0" fill="%s"%s/>`,
+					p[0], p[1], color, c.dataAttributes(c.markFields(series, i))))
+			}
+			continue
+		}
+		attrs := c.beginMark(svg, series, i)
+		if highlighted {
+			renderMarker(svg, point.marker(style), p[0], p[1], point.colorOr(color), attrs)
+		} else {
+			renderMarker(svg, style, p[0], p[1], color, attrs)
+		}
+		c.endMark(svg, series, i)
+	}
+}
+
 // Render renders the bar chart to an SVG string
 func (c *BarChart) Render() string {
 	var svg strings.Builder
+	patterns := c.newPatternFills()
 
 	// Apply auto-height if enabled
 	if c.AutoHeight {
@@ -907,62 +1050,14 @@ func (c *BarChart) Render() string {
 		legendAreaWidth = int(float64(c.Width) * c.LegendWidth)
 	}
 
-	// Start SVG with namespace
-	svg.WriteString(fmt.Sprintf(`<svg width="100%%" height="auto" viewBox="0 0 %d %d" preserveAspectRatio="xMidYMid meet" xmlns="http://www.w3.org/2000/svg">`, c.Width, c.Height))
-
-	// Add dark/light mode support if enabled
-	if c.DarkModeSupport {
-		svg.WriteString(fmt.Sprintf(`
-			<style>
-				:root {
-					--chart-bg: %s;
-					--chart-text: %s;
-					--chart-axis: %s;
-					--chart-grid: %s;
-				}
-				@media (prefers-color-scheme: dark) {
-					:root {
-						--chart-bg: %s;
-						--chart-text: %s;
-						--chart-axis: %s;
-						--chart-grid: %s;
-					}
-				}
-			</style>
-		`,
-			c.LightTheme.BackgroundColor,
-			c.LightTheme.TextColor,
-			c.LightTheme.AxisColor,
-			c.LightTheme.GridColor,
-			c.DarkTheme.BackgroundColor,
-			c.DarkTheme.TextColor,
-			c.DarkTheme.AxisColor,
-			c.DarkTheme.GridColor))
-
-		// Background with CSS variables
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="var(--chart-bg)"/>`, c.Width, c.Height))
-	} else {
-		// Background with static color
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, c.Width, c.Height, c.BackgroundColor))
-	}
+	// Start SVG with header, styles and background
+	c.renderHeader(&svg)
 
 	// Title
-	if c.ShowTitle && c.Title != "" {
-		if c.DarkModeSupport {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold" fill="var(--chart-text)">%s</text>`,
-				c.Width/2, c.Title))
-		} else {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold">%s</text>`,
-				c.Width/2, c.Title))
-		}
-	}
-
-	// Chart area dimensions (reduced by legend width if showing legend)
-	chartWidth := c.Width - c.Margin.Left - c.Margin.Right - legendAreaWidth
-	chartHeight := c.Height - c.Margin.Top - c.Margin.Bottom
+	c.renderTitle(&svg)
 
 	// Adjust legendX calculation for later use based on the reserved area
-	legendX := c.Width - c.Margin.Right - 150
+	legendX := c.Width - c.Margin.Right - c.legendColumnWidth()
 	if c.ShowLegend && c.LegendWidth > 0 && len(c.Series) > 0 {
 		legendX = c.Width - legendAreaWidth + 20
 	}
@@ -972,47 +1067,10 @@ func (c *BarChart) Render() string {
 
 	// Calculate scales
 	var maxValue float64
-	var maxStackedValue []float64
 
 	if hasMultipleSeries {
-		if c.Stacked {
-			// For stacked bars, we need to calculate the sum of values at each position
-			// Find the maximum number of data points across all series
-			maxDataPoints := 0
-			for _, series := range c.Series {
-				if len(series.Data) > maxDataPoints {
-					maxDataPoints = len(series.Data)
-				}
-			}
-
-			// Initialize the maxStackedValue slice
-			maxStackedValue = make([]float64, maxDataPoints)
-
-			// Calculate the sum of values at each position
-			for _, series := range c.Series {
-				for i, v := range series.Data {
-					if i < len(maxStackedValue) {
-						maxStackedValue[i] += v
-					}
-				}
-			}
-
-			// Find the maximum stacked value
-			for _, v := range maxStackedValue {
-				if v > maxValue {
-					maxValue = v
-				}
-			}
-		} else {
-			// For grouped bars, find the maximum value across all series
-			for _, series := range c.Series {
-				for _, v := range series.Data {
-					if v > maxValue {
-						maxValue = v
-					}
-				}
-			}
-		}
+		// The tallest stack, or the largest value for grouped bars
+		maxValue = c.maxStackValue()
 	} else {
 		// Legacy single series support
 		for _, v := range c.Data {
@@ -1022,21 +1080,40 @@ func (c *BarChart) Render() string {
 		}
 	}
 
+	// Include error bounds, reference lines, bands, trendlines and moving averages in the scale
+	// Percent stacks are scaled in percent, so annotations are read as percentages and the
+	// bounds and overlays, which are in the units of the data, are left out
+	var overlays []overlayLine
+	if c.stackMode() == StackPercent {
+		maxValue = math.Max(maxValue, c.annotationMax())
+	} else {
+		overlays = c.computeOverlays()
+		maxValue = math.Max(math.Max(maxValue, c.boundsMax()), math.Max(c.annotationMax(), overlayMax(overlays)))
+	}
+
 	// Add 10% padding to the max value
 	maxValue *= 1.1
 
+	// Make room for the y-axis ticks and title
+	defer c.reserveAxisSpace(maxValue)()
+
+	// Chart area dimensions (reduced by legend width if showing legend)
+	chartWidth := c.Width - c.Margin.Left - c.Margin.Right - legendAreaWidth
+
+	// Lay out the x-axis labels, wrapped or rotated labels and the x-axis title need a taller bottom margin
+	xLabels := c.layoutXLabels(float64(chartWidth) / math.Max(1, float64(c.categoryCount())))
+	defer c.reserveXLabelSpace(xLabels)()
+	chartHeight := c.Height - c.Margin.Top - c.Margin.Bottom
+
 	// Draw axes with theme support
-	if c.DarkModeSupport {
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="var(--chart-axis)" stroke-width="2"/>`,
-			c.Margin.Left, c.Height-c.Margin.Bottom, c.Width-c.Margin.Right, c.Height-c.Margin.Bottom))
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="var(--chart-axis)" stroke-width="2"/>`,
-			c.Margin.Left, c.Margin.Top, c.Margin.Left, c.Height-c.Margin.Bottom))
-	} else {
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black" stroke-width="2"/>`,
-			c.Margin.Left, c.Height-c.Margin.Bottom, c.Width-c.Margin.Right, c.Height-c.Margin.Bottom))
-		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black" stroke-width="2"/>`,
-			c.Margin.Left, c.Margin.Top, c.Margin.Left, c.Height-c.Margin.Bottom))
-	}
+	svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+		c.Margin.Left, c.Height-c.Margin.Bottom, c.Width-c.Margin.Right, c.Height-c.Margin.Bottom, c.axisColor(), c.axisWidth()))
+	svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+		c.Margin.Left, c.Margin.Top, c.Margin.Left, c.Height-c.Margin.Bottom, c.axisColor(), c.axisWidth()))
+
+	c.renderYTicks(&svg, maxValue, chartHeight)
+	plot := c.plotArea(chartWidth, chartHeight, maxValue)
+	c.renderAnnotationBands(&svg, plot)
 
 	// Draw data
 	if hasMultipleSeries {
@@ -1048,75 +1125,69 @@ func (c *BarChart) Render() string {
 			}
 		}
 
-		if c.Stacked {
-			// Draw stacked bars
-			barWidth := chartWidth / (maxDataPoints * 2)
+		if mode := c.stackMode(); mode != StackGrouped {
+			// Draw stacked bars, with stacks side by side when the series form several stack groups
+			names, stacks := c.barStacks()
+			groupWidth := chartWidth / maxDataPoints
+			barWidth := groupWidth / (len(stacks) + 1)
 
 			// For each data point position
 			for i := 0; i < maxDataPoints; i++ {
-				barX := c.Margin.Left + i*(chartWidth/maxDataPoints) + (chartWidth/maxDataPoints-barWidth)/2
+				for k, stack := range stacks {
+					barX := c.Margin.Left + i*groupWidth + k*barWidth + barWidth/2
 
-				// Track the current height for stacking
-				var currentStackHeight float64 = 0
-
-				// For each series, stack the bars
-				for seriesIndex, series := range c.Series {
-					if i >= len(series.Data) {
-						continue // Skip if this series doesn't have data for this position
-					}
+					// Track the current height for stacking
+					var currentStackHeight float64 = 0
 
-					value := series.Data[i]
-					if value <= 0 {
-						continue // Skip non-positive values
-					}
+					// For each series in the stack, stack the bars
+					for _, seriesIndex := range stack {
+						value := c.segmentValue(stack, seriesIndex, i)
+						if value <= 0 {
+							continue // Skip missing and non-positive values
+						}
 
-					// Calculate bar dimensions
-					barHeight := int(value / maxValue * float64(chartHeight))
-					barY := c.Height - c.Margin.Bottom - int(currentStackHeight/maxValue*float64(chartHeight)) - barHeight
+						// Calculate bar dimensions
+						barHeight := int(value / maxValue * float64(chartHeight))
+						barY := c.Height - c.Margin.Bottom - int(currentStackHeight/maxValue*float64(chartHeight)) - barHeight
 
-					// Update stack height for next bar
-					currentStackHeight += value
+						// Update stack height for next bar
+						currentStackHeight += value
 
-					// Determine color for this series
-					var color string
-					if seriesIndex < len(c.SeriesColors) {
-						color = c.SeriesColors[seriesIndex]
-					} else if len(c.Colors) > 0 {
-						color = c.Colors[seriesIndex%len(c.Colors)]
-					} else {
-						// Default colors if none specified
-						defaultColors := []string{"#4285F4", "#EA4335", "#FBBC05", "#34A853", "#8AB4F8", "#F6AEA9", "#FDE293", "#A8DAB5"}
-						color = defaultColors[seriesIndex%len(defaultColors)]
-					}
+						// Draw the bar
+						point, _ := c.pointStyle(seriesIndex, i)
+						attrs := c.beginMark(&svg, seriesIndex, i)
+						svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"%s%s/>`,
+							barX, barY, barWidth, barHeight, patterns.fill(seriesIndex, point.colorOr(c.seriesColor(seriesIndex))), point.attributes(c.axisColor()), attrs))
 
-					// Draw the bar
-					svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
-						barX, barY, barWidth, barHeight, color))
-
-					// Add value text in the middle of each segment
-					if barHeight > 20 { // Only show text if bar is tall enough
-						if c.DarkModeSupport {
-							svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%.0f</text>`,
-								barX+barWidth/2, barY+barHeight/2+5, value))
-						} else {
-							svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="white">%.0f</text>`,
-								barX+barWidth/2, barY+barHeight/2+5, value))
+						// Add the value, or its share of the stack in percent mode, in the middle of each segment
+						label := fmt.Sprintf("%.0f%s", value, escapeText(c.YAxisUnit))
+						if mode == StackPercent {
+							label = fmt.Sprintf("%.0f%%", value)
 						}
+						if barHeight > 20 { // Only show text if bar is tall enough
+							if c.DarkModeSupport {
+								svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label" fill="var(--chart-text)">%s</text>`,
+									barX+barWidth/2, barY+barHeight/2+5, label))
+							} else {
+								svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label" fill="white">%s</text>`,
+									barX+barWidth/2, barY+barHeight/2+5, label))
+							}
+						}
+						c.endMark(&svg, seriesIndex, i)
 					}
-				}
 
-				// Add total value on top of the stack if there are multiple series
-				if len(c.Series) > 1 && i < len(maxStackedValue) {
-					totalValue := maxStackedValue[i]
-					totalBarHeight := int(totalValue / maxValue * float64(chartHeight))
-					totalBarY := c.Height - c.Margin.Bottom - totalBarHeight
+					// Add total value on top of the stack if it has multiple series, percent stacks all total 100%
+					totalBarY := c.Height - c.Margin.Bottom - int(currentStackHeight/maxValue*float64(chartHeight))
+					if len(stack) > 1 && mode != StackPercent && currentStackHeight > 0 {
+						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label"%s>%.0f%s</text>`,
+							barX+barWidth/2, totalBarY-5, c.textFill(), currentStackHeight, escapeText(c.YAxisUnit)))
+						totalBarY -= int(c.fontSize("label")) + 2
+					}
 
-					if c.DarkModeSupport {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%.0f</text>`,
-							barX+barWidth/2, totalBarY-5, totalValue))
-					} else {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="black">%.0f</text>`,
-							barX+barWidth/2, totalBarY-5, totalValue))
+					// Name the stack group above the stack
+					if names[k] != "" && currentStackHeight > 0 {
+						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-tick"%s>%s</text>`,
+							barX+barWidth/2, totalBarY-5, c.textFill(), escapeText(names[k])))
 					}
 				}
 			}
@@ -1136,8 +1207,8 @@ func (c *BarChart) Render() string {
 					}
 
 					value := series.Data[i]
-					if value <= 0 {
-						continue // Skip non-positive values
+					if IsMissing(value) || value <= 0 {
+						continue // Skip missing and non-positive values
 					}
 
 					// Calculate bar dimensions
@@ -1153,22 +1224,19 @@ func (c *BarChart) Render() string {
 						color = c.Colors[seriesIndex%len(c.Colors)]
 					} else {
 						// Default colors if none specified
-						defaultColors := []string{"#4285F4", "#EA4335", "#FBBC05", "#34A853", "#8AB4F8", "#F6AEA9", "#FDE293", "#A8DAB5"}
-						color = defaultColors[seriesIndex%len(defaultColors)]
+						color = autoPalette[seriesIndex%len(autoPalette)]
 					}
 
 					// Draw the bar
-					svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
-						barX, barY, barWidth, barHeight, color))
+					point, _ := c.pointStyle(seriesIndex, i)
+					attrs := c.beginMark(&svg, seriesIndex, i)
+					svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"%s%s/>`,
+						barX, barY, barWidth, barHeight, patterns.fill(seriesIndex, point.colorOr(color)), point.attributes(c.axisColor()), attrs))
 
 					// Add value text on top of bar
-					if c.DarkModeSupport {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%.0f</text>`,
-							barX+barWidth/2, barY-5, value))
-					} else {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="black">%.0f</text>`,
-							barX+barWidth/2, barY-5, value))
-					}
+					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label"%s>%.0f%s</text>`,
+						barX+barWidth/2, barY-5, c.textFill(), value, escapeText(c.YAxisUnit)))
+					c.endMark(&svg, seriesIndex, i)
 				}
 			}
 		}
@@ -1187,37 +1255,27 @@ func (c *BarChart) Render() string {
 					color = c.Colors[i%len(c.Colors)]
 				} else {
 					// Default colors if none specified
-					defaultColors := []string{"#4285F4", "#EA4335", "#FBBC05", "#34A853", "#8AB4F8", "#F6AEA9", "#FDE293", "#A8DAB5"}
-					color = defaultColors[i%len(defaultColors)]
+					color = autoPalette[i%len(autoPalette)]
 				}
 
 				// Draw legend item
+				c.beginLegendItem(&svg, series.Name)
 				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="15" height="15" fill="%s"/>`,
-					legendX, legendY+i*25, color))
+					legendX, legendY+i*25, patterns.fill(i, color)))
 
-				if c.DarkModeSupport {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-						legendX+25, legendY+i*25+12, series.Name))
-				} else {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12">%s</text>`,
-						legendX+25, legendY+i*25+12, series.Name))
-				}
+				c.renderLegendText(&svg, legendX+25, legendY+i*25+12, series.Name, float64(c.Width-legendX-30))
+				c.endLegendItem(&svg)
 			}
+			c.renderOverlayLegend(&svg, legendX, legendY+len(c.Series)*25, overlays)
 		}
 
 		// Draw labels on x-axis if available
 		if len(c.Labels) > 0 {
-			for i := 0; i < maxDataPoints && i < len(c.Labels); i++ {
-				x := c.Margin.Left + i*(chartWidth/maxDataPoints) + (chartWidth/maxDataPoints)/2
-
-				if c.DarkModeSupport {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-						x, c.Height-c.Margin.Bottom+20, c.Labels[i]))
-				} else {
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12">%s</text>`,
-						x, c.Height-c.Margin.Bottom+20, c.Labels[i]))
-				}
+			positions := make([]int, maxDataPoints)
+			for i := range positions {
+				positions[i] = c.Margin.Left + i*(chartWidth/maxDataPoints) + (chartWidth/maxDataPoints)/2
 			}
+			c.renderXLabels(&svg, positions, xLabels)
 		}
 	} else if len(c.Data) > 0 {
 		// Legacy single series support
@@ -1225,6 +1283,9 @@ func (c *BarChart) Render() string {
 
 		// Draw bars
 		for i, v := range c.Data {
+			if IsMissing(v) {
+				continue // Skip missing values
+			}
 			barHeight := int(v / maxValue * float64(chartHeight))
 			barX := c.Margin.Left + i*(chartWidth/len(c.Data)) + (chartWidth/len(c.Data)-barWidth)/2
 			barY := c.Height - c.Margin.Bottom - barHeight
@@ -1233,36 +1294,37 @@ func (c *BarChart) Render() string {
 			colorIndex := i % len(c.Colors)
 			color := c.Colors[colorIndex]
 
-			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
-				barX, barY, barWidth, barHeight, color))
+			point, _ := c.pointStyle(0, i)
+			attrs := c.beginMark(&svg, 0, i)
+			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"%s%s/>`,
+				barX, barY, barWidth, barHeight, patterns.fill(i, point.colorOr(color)), point.attributes(c.axisColor()), attrs))
 
 			// Add value text on top of bar with dark mode support
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%.0f</text>`,
-					barX+barWidth/2, barY-5, v))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="black">%.0f</text>`,
-					barX+barWidth/2, barY-5, v))
-			}
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label"%s>%.0f%s</text>`,
+				barX+barWidth/2, barY-5, c.textFill(), v, escapeText(c.YAxisUnit)))
+			c.endMark(&svg, 0, i)
 		}
 
 		// Draw labels if available
 		if len(c.Labels) > 0 {
-			for i := 0; i < len(c.Data); i++ {
-				if i < len(c.Labels) {
-					barX := c.Margin.Left + i*(chartWidth/len(c.Data)) + (chartWidth/len(c.Data))/2
-					if c.DarkModeSupport {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-							barX, c.Height-c.Margin.Bottom+20, c.Labels[i]))
-					} else {
-						svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12">%s</text>`,
-							barX, c.Height-c.Margin.Bottom+20, c.Labels[i]))
-					}
-				}
+			positions := make([]int, len(c.Data))
+			for i := range positions {
+				positions[i] = c.Margin.Left + i*(chartWidth/len(c.Data)) + (chartWidth/len(c.Data))/2
 			}
+			c.renderXLabels(&svg, positions, xLabels)
 		}
 	}
 
+	// Error bars go over all bars so neighboring bars don't hide them, percent stacks have no value scale for them
+	for series := 0; series < max(1, len(c.Series)) && c.stackMode() != StackPercent; series++ {
+		c.renderErrorBars(&svg, plot, series, math.Min(12, plot.halfSlot/float64(max(1, len(c.Series)))))
+	}
+	c.renderOverlays(&svg, plot, overlays, !c.ShowLegend || len(c.Series) == 0)
+	c.renderAnnotationMarks(&svg, plot)
+	c.renderAxisTitles(&svg, xLabels, maxValue, chartWidth, chartHeight)
+	c.renderScript(&svg, plot, false)
+
+	patterns.writeDefs(&svg)
 	svg.WriteString("</svg>")
 	return svg.String()
 }
@@ -1270,6 +1332,7 @@ func (c *BarChart) Render() string {
 // Render renders the pie chart to an SVG string
 func (c *PieChart) Render() string {
 	var svg strings.Builder
+	patterns := c.newPatternFills()
 
 	// Apply auto-height if enabled
 	if c.AutoHeight {
@@ -1282,61 +1345,19 @@ func (c *PieChart) Render() string {
 		legendAreaWidth = int(float64(c.Width) * c.LegendWidth)
 	}
 
-	// Start SVG with namespace
-	svg.WriteString(fmt.Sprintf(`<svg width="100%%" height="auto" viewBox="0 0 %d %d" preserveAspectRatio="xMidYMid meet" xmlns="http://www.w3.org/2000/svg">`, c.Width, c.Height))
-
-	// Add dark/light mode support if enabled
-	if c.DarkModeSupport {
-		svg.WriteString(fmt.Sprintf(`
-			<style>
-				:root {
-					--chart-bg: %s;
-					--chart-text: %s;
-					--chart-axis: %s;
-					--chart-grid: %s;
-				}
-				@media (prefers-color-scheme: dark) {
-					:root {
-						--chart-bg: %s;
-						--chart-text: %s;
-						--chart-axis: %s;
-						--chart-grid: %s;
-					}
-				}
-			</style>
-		`,
-			c.LightTheme.BackgroundColor,
-			c.LightTheme.TextColor,
-			c.LightTheme.AxisColor,
-			c.LightTheme.GridColor,
-			c.DarkTheme.BackgroundColor,
-			c.DarkTheme.TextColor,
-			c.DarkTheme.AxisColor,
-			c.DarkTheme.GridColor))
-
-		// Background with CSS variables
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="var(--chart-bg)"/>`, c.Width, c.Height))
-	} else {
-		// Background with static color
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, c.Width, c.Height, c.BackgroundColor))
-	}
+	// Start SVG with header, styles and background
+	c.renderHeader(&svg)
 
 	// Title
-	if c.ShowTitle && c.Title != "" {
-		if c.DarkModeSupport {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold" fill="var(--chart-text)">%s</text>`,
-				c.Width/2, c.Title))
-		} else {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold">%s</text>`,
-				c.Width/2, c.Title))
-		}
-	}
+	c.renderTitle(&svg)
 
-	// Calculate total
+	// Slices as drawn, after sorting and grouping, and their total
+	slices := c.pieSlices()
 	var total float64
-	for _, v := range c.Data {
-		total += v
+	for _, slice := range slices {
+		total += slice.value
 	}
+	outside := c.LabelMode == PieLabelsOutside
 
 	// Center and radius - account for reserved legend width
 	var adjustedWidth int
@@ -1355,22 +1376,53 @@ func (c *PieChart) Render() string {
 	radius := int(math.Min(float64(adjustedWidth-c.Margin.Left-c.Margin.Right),
 		float64(c.Height-c.Margin.Top-c.Margin.Bottom))) / 2
 
+	// Outside labels and exploded slices need room around the pie
+	if outside {
+		radius = radius * 7 / 10
+	} else if len(c.Exploded) > 0 {
+		radius = radius * 9 / 10
+	}
+
 	innerRadius := int(float64(radius) * c.DonutHolePercentage)
 
+	// Right edge of the space for outside labels
+	labelRight := c.Width - c.Margin.Right
+	if c.ShowLegend && c.LegendWidth > 0 && len(c.Labels) > 0 {
+		labelRight = c.Width - legendAreaWidth
+	}
+
 	// Draw pie slices
-	if len(c.Data) > 0 && total > 0 {
-		var startAngle float64
+	if len(slices) > 0 && total > 0 {
+		startAngle := c.StartAngle * math.Pi / 180
+		var labels []pieLabel
+
+		// Leader lines bend outside the pie, and outside exploded slices when there are any
+		reach := float64(radius)
+		for _, slice := range slices {
+			if c.exploded(slice) {
+				reach = float64(radius) * 1.08
+			}
+		}
 
-		for i, v := range c.Data {
+		for k, slice := range slices {
 			// Calculate angles
-			sliceAngle := v / total * 2 * math.Pi
+			sliceAngle := slice.value / total * 2 * math.Pi
 			endAngle := startAngle + sliceAngle
+			labelAngle := startAngle + sliceAngle/2
+
+			// Exploded slices move out along the middle of the slice
+			var explodeX, explodeY float64
+			if c.exploded(slice) {
+				explodeX = math.Cos(labelAngle) * float64(radius) * 0.08
+				explodeY = math.Sin(labelAngle) * float64(radius) * 0.08
+			}
+			cx, cy := centerX+int(explodeX), centerY+int(explodeY)
 
 			// Calculate points
-			x1 := centerX + int(math.Cos(startAngle)*float64(radius))
-			y1 := centerY + int(math.Sin(startAngle)*float64(radius))
-			x2 := centerX + int(math.Cos(endAngle)*float64(radius))
-			y2 := centerY + int(math.Sin(endAngle)*float64(radius))
+			x1 := cx + int(math.Cos(startAngle)*float64(radius))
+			y1 := cy + int(math.Sin(startAngle)*float64(radius))
+			x2 := cx + int(math.Cos(endAngle)*float64(radius))
+			y2 := cy + int(math.Sin(endAngle)*float64(radius))
 
 			// Determine large arc flag
 			largeArcFlag := 0
@@ -1378,28 +1430,66 @@ func (c *PieChart) Render() string {
 				largeArcFlag = 1
 			}
 
-			// Determine color (cycle through available colors)
-			colorIndex := i % len(c.Colors)
-			color := c.Colors[colorIndex]
+			// The "Other" slice has no data index, it takes the pattern of its position
+			color := c.sliceColor(slice)
+			point, _ := c.pointStyle(0, slice.index)
+			link, fields := c.markURL(0, slice.index), markFields{label: slice.label, value: slice.value, percent: slice.value / total * 100}
+			attrs := c.openMark(&svg, link, fields)
+			patternIndex := slice.index
+			if patternIndex < 0 {
+				patternIndex = k
+			}
 
 			// Draw path
-			if c.DonutHolePercentage > 0 {
+			if len(slices) == 1 {
+				// A full circle can't be drawn as one arc, a full donut is a ring cut out of it
+				if c.DonutHolePercentage > 0 {
+					svg.WriteString(fmt.Sprintf(`<path d="M%d,%d A%d,%d 0 1,1 %d,%d A%d,%d 0 1,1 %d,%d Z M%d,%d A%d,%d 0 1,0 %d,%d A%d,%d 0 1,0 %d,%d Z" fill-rule="evenodd" fill="%s"%s%s/>`,
+						cx-radius, cy, radius, radius, cx+radius, cy, radius, radius, cx-radius, cy,
+						cx-innerRadius, cy, innerRadius, innerRadius, cx+innerRadius, cy, innerRadius, innerRadius, cx-innerRadius, cy,
+						patterns.fill(patternIndex, color), point.attributes(c.axisColor()), attrs))
+				} else {
+					svg.WriteString(fmt.Sprintf(`<circle cx="%d" cy="%d" r="%d" fill="%s"%s%s/>`,
+						cx, cy, radius, patterns.fill(patternIndex, color), point.attributes(c.axisColor()), attrs))
+				}
+			} else if c.DonutHolePercentage > 0 {
 				// For donut chart, draw more complex path
-				x1Inner := centerX + int(math.Cos(startAngle)*float64(innerRadius))
-				y1Inner := centerY + int(math.Sin(startAngle)*float64(innerRadius))
-				x2Inner := centerX + int(math.Cos(endAngle)*float64(innerRadius))
-				y2Inner := centerY + int(math.Sin(endAngle)*float64(innerRadius))
+				x1Inner := cx + int(math.Cos(startAngle)*float64(innerRadius))
+				y1Inner := cy + int(math.Sin(startAngle)*float64(innerRadius))
+				x2Inner := cx + int(math.Cos(endAngle)*float64(innerRadius))
+				y2Inner := cy + int(math.Sin(endAngle)*float64(innerRadius))
 
-				svg.WriteString(fmt.Sprintf(`<path d="M%d,%d L%d,%d A%d,%d 0 %d,1 %d,%d L%d,%d A%d,%d 0 %d,0 %d,%d Z" fill="%s"/>`,
-					x1Inner, y1Inner, x1, y1, radius, radius, largeArcFlag, x2, y2, x2Inner, y2Inner, innerRadius, innerRadius, largeArcFlag, x1Inner, y1Inner, color))
+				svg.WriteString(fmt.Sprintf(`<path d="M%d,%d L%d,%d A%d,%d 0 %d,1 %d,%d L%d,%d A%d,%d 0 %d,0 %d,%d Z" fill="%s"%s%s/>`,
+					x1Inner, y1Inner, x1, y1, radius, radius, largeArcFlag, x2, y2, x2Inner, y2Inner, innerRadius, innerRadius, largeArcFlag, x1Inner, y1Inner, patterns.fill(patternIndex, color), point.attributes(c.axisColor()), attrs))
 			} else {
 				// For regular pie chart, draw simple wedge
-				svg.WriteString(fmt.Sprintf(`<path d="M%d,%d L%d,%d A%d,%d 0 %d,1 %d,%d L%d,%d Z" fill="%s"/>`,
-					centerX, centerY, x1, y1, radius, radius, largeArcFlag, x2, y2, centerX, centerY, color))
+				svg.WriteString(fmt.Sprintf(`<path d="M%d,%d L%d,%d A%d,%d 0 %d,1 %d,%d L%d,%d Z" fill="%s"%s%s/>`,
+					cx, cy, x1, y1, radius, radius, largeArcFlag, x2, y2, cx, cy, patterns.fill(patternIndex, color), point.attributes(c.axisColor()), attrs))
+			}
+			c.closeMark(&svg, link, fields)
+
+			percentage := slice.value / total * 100
+
+			// Outside labels are collected and laid out together once all slices are drawn
+			if outside {
+				anchorX := float64(centerX) + explodeX + math.Cos(labelAngle)*float64(radius)
+				anchorY := float64(centerY) + explodeY + math.Sin(labelAngle)*float64(radius)
+				label := pieLabel{
+					text:     fmt.Sprintf("%s %.1f%%", slice.label, percentage),
+					anchorX:  anchorX,
+					anchorY:  anchorY,
+					y:        anchorY + 4,
+					right:    math.Cos(labelAngle) >= 0,
+					maxWidth: float64(centerX) - reach - 23 - float64(c.Margin.Left),
+				}
+				if label.right {
+					label.maxWidth = float64(labelRight-centerX-23) - reach
+				}
+				label.full = label.text
+				labels = append(labels, label)
+				startAngle = endAngle
+				continue
 			}
-
-			// Label position (middle of slice)
-			labelAngle := startAngle + sliceAngle/2
 
 			// Adjust label distance based on slice size
 			// For smaller slices, move labels slightly outward
@@ -1412,11 +1502,8 @@ func (c *PieChart) Render() string {
 				labelDistance = float64(radius) * 0.7
 			}
 
-			labelX := centerX + int(math.Cos(labelAngle)*labelDistance)
-			labelY := centerY + int(math.Sin(labelAngle)*labelDistance)
-
-			// Draw percentage
-			percentage := v / total * 100
+			labelX := cx + int(math.Cos(labelAngle)*labelDistance)
+			labelY := cy + int(math.Sin(labelAngle)*labelDistance)
 
 			// For very small slices, show tooltip but simpler label
 			if sliceAngle < math.Pi/15 { // Less than 12 degrees
@@ -1426,18 +1513,22 @@ func (c *PieChart) Render() string {
 						labelX, labelY, percentage))
 				} else {
 					// Show percentage with tooltip
-					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="10" fill="white">%.0f%%<title>%.1f%%</title></text>`,
+					svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-tick" fill="white">%.0f%%<title>%.1f%%</title></text>`,
 						labelX, labelY, percentage, percentage))
 				}
 			} else {
 				// Normal percentage text
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" font-family="Arial" font-size="12" fill="white">%.1f%%</text>`,
+				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label" fill="white">%.1f%%</text>`,
 					labelX, labelY, percentage))
 			}
 
 			startAngle = endAngle
 		}
 
+		if outside {
+			c.renderPieLabels(&svg, labels, float64(centerX), reach)
+		}
+
 		// Draw legend
 		if c.ShowLegend && len(c.Labels) > 0 {
 			// Position legend based on available space
@@ -1452,7 +1543,7 @@ func (c *PieChart) Render() string {
 			legendY := c.Margin.Top
 
 			// Calculate total height needed for the legend
-			legendHeight := len(c.Labels) * 25
+			legendHeight := len(slices) * 25
 
 			// Adjust legend position if it would go outside chart area
 			if legendY+legendHeight > c.Height-c.Margin.Bottom {
@@ -1460,48 +1551,39 @@ func (c *PieChart) Render() string {
 				legendY = int(math.Max(float64(c.Margin.Top), float64(c.Height-c.Margin.Bottom-legendHeight)))
 			}
 
-			for i, label := range c.Labels {
-				if i < len(c.Data) {
-					colorIndex := i % len(c.Colors)
-					color := c.Colors[colorIndex]
+			for k, slice := range slices {
+				label := slice.label
+				color := c.sliceColor(slice)
+				patternIndex := slice.index
+				if patternIndex < 0 {
+					patternIndex = k
+				}
 
-					// Truncate label if needed
-					displayLabel := label
-					if c.MaxLabelLength > 0 && len(label) > c.MaxLabelLength {
-						displayLabel = label[:c.MaxLabelLength] + "…"
-					}
+				// Truncate label if needed: first to MaxLabelLength characters, then to the space left of the chart edge
+				displayLabel := label
+				if runes := []rune(label); c.MaxLabelLength > 0 && len(runes) > c.MaxLabelLength {
+					displayLabel = string(runes[:c.MaxLabelLength]) + "…"
+				}
+				displayLabel = c.truncateText(displayLabel, "legend", float64(c.Width-legendX-25))
 
-					// Draw color box
-					svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="15" height="15" fill="%s"/>`,
-						legendX, legendY, color))
-
-					// Draw label with tooltip if needed
-					if c.ShowTooltips && displayLabel != label {
-						// Add label with tooltip
-						if c.DarkModeSupport {
-							svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12" fill="var(--chart-text)">%s<title>%s</title></text>`,
-								legendX+20, legendY+12, displayLabel, label))
-						} else {
-							svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12">%s<title>%s</title></text>`,
-								legendX+20, legendY+12, displayLabel, label))
-						}
-					} else {
-						// Regular label without tooltip
-						if c.DarkModeSupport {
-							svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12" fill="var(--chart-text)">%s</text>`,
-								legendX+20, legendY+12, displayLabel))
-						} else {
-							svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="12">%s</text>`,
-								legendX+20, legendY+12, displayLabel))
-						}
-					}
+				// Draw color box
+				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="15" height="15" fill="%s"/>`,
+					legendX, legendY, patterns.fill(patternIndex, color)))
 
-					legendY += 25
+				// Draw label with tooltip if needed
+				tooltip := ""
+				if c.ShowTooltips && displayLabel != label {
+					tooltip = "<title>" + escapeText(label) + "</title>"
 				}
+				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-legend"%s>%s%s</text>`,
+					legendX+20, legendY+12, c.textFill(), escapeText(displayLabel), tooltip))
+
+				legendY += 25
 			}
 		}
 	}
 
+	patterns.writeDefs(&svg)
 	svg.WriteString("</svg>")
 	return svg.String()
 }
@@ -1510,105 +1592,96 @@ func (c *PieChart) Render() string {
 func (c *HeatmapChart) Render() string {
 	var svg strings.Builder
 
-	// Start SVG with namespace
-	svg.WriteString(fmt.Sprintf(`<svg width="100%%" height="auto" viewBox="0 0 %d %d" preserveAspectRatio="xMidYMid meet" xmlns="http://www.w3.org/2000/svg">`, c.Width, c.Height))
-
-	// Add dark/light mode support if enabled
-	if c.DarkModeSupport {
-		svg.WriteString(fmt.Sprintf(`
-			<style>
-				:root {
-					--chart-bg: %s;
-					--chart-text: %s;
-					--chart-axis: %s;
-					--chart-grid: %s;
-				}
-				@media (prefers-color-scheme: dark) {
-					:root {
-						--chart-bg: %s;
-						--chart-text: %s;
-						--chart-axis: %s;
-						--chart-grid: %s;
-					}
-				}
-			</style>
-		`,
-			c.LightTheme.BackgroundColor,
-			c.LightTheme.TextColor,
-			c.LightTheme.AxisColor,
-			c.LightTheme.GridColor,
-			c.DarkTheme.BackgroundColor,
-			c.DarkTheme.TextColor,
-			c.DarkTheme.AxisColor,
-			c.DarkTheme.GridColor))
-
-		// Background with CSS variables
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="var(--chart-bg)"/>`, c.Width, c.Height))
-	} else {
-		// Background with static color
-		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, c.Width, c.Height, c.BackgroundColor))
-	}
+	// Start SVG with header, styles and background
+	c.renderHeader(&svg)
 
 	// Title
-	if c.ShowTitle && c.Title != "" {
-		if c.DarkModeSupport {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold" fill="var(--chart-text)">%s</text>`,
-				c.Width/2, c.Title))
-		} else {
-			svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" font-family="Arial" font-size="20" font-weight="bold">%s</text>`,
-				c.Width/2, c.Title))
-		}
+	c.renderTitle(&svg)
+
+	// Matrix heatmaps use a categorical grid instead of the calendar layout
+	if len(c.Matrix) > 0 {
+		c.renderMatrix(&svg)
+		svg.WriteString("</svg>")
+		return svg.String()
 	}
 
-	// Initialize dates and values map
-	dateMap := make(map[string]float64)
-	var dates []time.Time
+	// Aggregate values by calendar day, so several entries on one day combine
+	// instead of overwriting each other
+	days := c.aggregateDays()
 
-	// Parse dates from labels
-	for i, label := range c.Labels {
-		if i < len(c.Data) {
-			date, err := time.Parse(c.DateFormat, label)
-			if err == nil {
-				dateMap[label] = c.Data[i]
-				dates = append(dates, date)
-			}
-		}
+	// If no data, return empty SVG
+	if len(days) == 0 {
+		svg.WriteString("</svg>")
+		return svg.String()
 	}
 
 	// Sort dates
+	dates := make([]time.Time, 0, len(days))
+	values := make([]float64, 0, len(days))
+	for _, day := range days {
+		dates = append(dates, day.date)
+		values = append(values, day.value())
+	}
 	sort.Slice(dates, func(i, j int) bool {
 		return dates[i].Before(dates[j])
 	})
 
-	// If no data, return empty SVG
-	if len(dates) == 0 {
-		svg.WriteString("</svg>")
-		return svg.String()
+	// Each strip is one row of week columns; multi-year data gets one strip per year
+	type calendarStrip struct {
+		from, to time.Time // Days shown in this strip
+		year     int       // Year label, 0 for a single strip
 	}
-
-	// Find the first Sunday before the start date
 	startDate := dates[0]
-	daysToSubtract := int(startDate.Weekday())
-	firstSunday := startDate.AddDate(0, 0, -daysToSubtract)
-
-	// Find the last Saturday after the end date
 	endDate := dates[len(dates)-1]
-	daysToAdd := 6 - int(endDate.Weekday())
-	lastSaturday := endDate.AddDate(0, 0, daysToAdd)
+	strips := []calendarStrip{{from: startDate, to: endDate}}
+	if c.SplitYears && startDate.Year() != endDate.Year() && c.weeksBetween(startDate, endDate) > 53 {
+		strips = strips[:0]
+		for year := startDate.Year(); year <= endDate.Year(); year++ {
+			strips = append(strips, calendarStrip{
+				from: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+				to:   time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+				year: year,
+			})
+		}
+	}
+	multiYear := strips[0].year != 0
+
+	// Calculate total weeks, using the widest strip
+	totalWeeks := 0
+	for _, strip := range strips {
+		if weeks := c.weeksBetween(strip.from, strip.to); weeks > totalWeeks {
+			totalWeeks = weeks
+		}
+	}
 
-	// Calculate total weeks
-	totalDays := int(lastSaturday.Sub(firstSunday).Hours()/24) + 1
-	totalWeeks := totalDays / 7
+	// Day labels in column order, rotated so the week starts on WeekStart
+	dayLabels := []string{"S", "M", "T", "W", "T", "F", "S"} // Sunday to Saturday
+	if len(c.DayLabels) == 7 {
+		// Use user-defined labels if they've specified exactly 7 labels (one for each day)
+		dayLabels = c.DayLabels
+	}
 
 	// Calculate cell size based on available space
-	dayLabelWidth := 15 // Width for day labels
-	availableWidth := c.Width - c.Margin.Left - c.Margin.Right - dayLabelWidth
+	dayLabelWidth := 15 // Width for day labels, widened for longer custom labels
+	for _, label := range dayLabels {
+		if w := int(math.Ceil(c.textWidth(label, "tick"))) + 8; w > dayLabelWidth {
+			dayLabelWidth = w
+		}
+	}
+	yearLabelWidth := 0
+	stripGap := 0 // Vertical space between strips for the month labels
+	if multiYear {
+		yearLabelWidth = 20
+		stripGap = 20
+	}
+	availableWidth := c.Width - c.Margin.Left - c.Margin.Right - dayLabelWidth - yearLabelWidth
 	availableHeight := c.Height - c.Margin.Top - c.Margin.Bottom - 50 // 50px for title and month labels
 
 	// Calculate cell size to fit within the available space
-	// We need to fit totalWeeks columns and 7 rows
+	// We need to fit totalWeeks columns and 7 rows per strip
+	rows := 7 * len(strips)
 	maxCellWidth := (availableWidth - (totalWeeks-1)*c.CellSpacing) / totalWeeks
-	maxCellHeight := (availableHeight - 6*c.CellSpacing) / 7 // 6 spaces between 7 rows
+	maxCellHeight := (availableHeight - (rows-1)*c.CellSpacing - (len(strips)-1)*stripGap) / rows
 
 	// Use the smaller of the two to maintain square cells
 	calculatedCellSize := math.Min(float64(maxCellWidth), float64(maxCellHeight))
@@ -1622,23 +1695,306 @@ func (c *HeatmapChart) Render() string {
 	}
 
 	// Find min and max values for color scaling
-	maxVal := c.MaxValue
-	minVal := c.MinValue
+	minVal, maxVal := c.valueRange(values)
+
+	// Starting position for the grid
+	startX := c.Margin.Left + yearLabelWidth + dayLabelWidth // Space for year and day labels
+	startY := c.Margin.Top + 50                              // Space for title and month labels
+	stripHeight := 7*(cellSize+c.CellSpacing) + stripGap
+
+	for s, strip := range strips {
+		stripY := startY + s*stripHeight
+
+		// Year label, rotated along the left edge of the strip
+		if multiYear {
+			labelX := c.Margin.Left + yearLabelWidth/2
+			labelY := stripY + 7*(cellSize+c.CellSpacing)/2
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-label" style="font-weight: bold" text-anchor="middle" transform="rotate(-90 %d %d)"%s>%d</text>`,
+				labelX, labelY, labelX, labelY, c.textFill(), strip.year))
+		}
+
+		// Draw day labels
+		for i := 0; i < 7; i++ {
+			label := dayLabels[(int(c.WeekStart)+i)%7]
+			labelY := stripY + i*(cellSize+c.CellSpacing) + cellSize/2 + 5
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="end"%s>%s</text>`,
+				startX-5, labelY, c.textFill(), escapeText(label)))
+		}
+
+		// Draw the heatmap grid, starting on the week containing the first day
+		currentDate := c.weekStartOf(strip.from)
+		lastMonth := time.Month(0)
+		for week := 0; week < c.weeksBetween(strip.from, strip.to); week++ {
+			// Check if we need to draw month label, using the first day shown in this column
+			columnDate := currentDate
+			if columnDate.Before(strip.from) {
+				columnDate = strip.from
+			}
+			if columnDate.Day() <= 7 && columnDate.Month() != lastMonth {
+				// This is the first week of the month
+				lastMonth = columnDate.Month()
+				monthLabel := c.MonthLabels[columnDate.Month()-1]
+				labelX := startX + week*(cellSize+c.CellSpacing) + cellSize/2
+				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="middle"%s>%s</text>`,
+					labelX, stripY-5, c.textFill(), monthLabel))
+			}
+
+			for day := 0; day < 7; day++ {
+				// Skip days that belong to the neighbouring year
+				if multiYear && (currentDate.Before(strip.from) || currentDate.After(strip.to)) {
+					currentDate = currentDate.AddDate(0, 0, 1)
+					continue
+				}
+
+				// Days with data are colored by value, days without keep the empty color
+				tooltip := "no data"
+				dataValue := Missing()
+				color := c.EmptyColor
+				if entry, ok := days[currentDate.Format("2006-01-02")]; ok {
+					dataValue = entry.value()
+					tooltip = fmt.Sprintf(c.ValueFormat, dataValue)
+					color = c.cellColor(dataValue, minVal, maxVal)
+				}
+
+				// Calculate cell position
+				cellX := startX + week*(cellSize+c.CellSpacing)
+				cellY := stripY + day*(cellSize+c.CellSpacing)
+
+				// Draw cell with a tooltip, data attributes carry the ISO date and tooltips the formatted one
+				fields := markFields{label: currentDate.Format("2006-01-02"), value: dataValue, percent: Missing()}
+				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"%s%s>`,
+					cellX, cellY, cellSize, cellSize, c.CellRounding, c.CellRounding, color, c.dataAttributes(fields), c.markClass()))
+				fields.label = currentDate.Format(c.TooltipFormat)
+				svg.WriteString(fmt.Sprintf(`<title>%s</title></rect>`,
+					escapeText(c.cellTooltip(fields, fields.label+": "+tooltip))))
+
+				// Move to next day
+				currentDate = currentDate.AddDate(0, 0, 1)
+			}
+		}
+	}
+
+	// Add legend below the last strip
+	if c.ShowLegend {
+		legendY := startY + (len(strips)-1)*stripHeight + 7*(cellSize+c.CellSpacing) + 30
+		c.renderLegend(&svg, c.Margin.Left, legendY, cellSize, minVal, maxVal)
+	}
+
+	svg.WriteString("</svg>")
+	return svg.String()
+}
+
+// calendarDay accumulates the values that fall on one calendar day
+type calendarDay struct {
+	date                time.Time
+	sum, min, max, last float64
+	count               int
+	aggregation         string
+}
+
+// add combines another value into the day
+func (d *calendarDay) add(v float64) {
+	if d.count == 0 || v < d.min {
+		d.min = v
+	}
+	if d.count == 0 || v > d.max {
+		d.max = v
+	}
+	d.sum += v
+	d.last = v
+	d.count++
+}
+
+// value returns the aggregated value for the day
+func (d *calendarDay) value() float64 {
+	switch d.aggregation {
+	case "mean":
+		return d.sum / float64(d.count)
+	case "max":
+		return d.max
+	case "min":
+		return d.min
+	case "sum":
+		return d.sum
+	}
+	return d.last
+}
+
+// aggregateDays parses the labels as dates and groups their values by day, keyed by "2006-01-02"
+func (c *HeatmapChart) aggregateDays() map[string]*calendarDay {
+	days := make(map[string]*calendarDay)
+	for i, label := range c.Labels {
+		if i >= len(c.Data) {
+			break
+		}
+		date, err := time.Parse(c.DateFormat, label)
+		if err != nil || IsMissing(c.Data[i]) {
+			continue
+		}
+		// Drop the time of day so timestamps land on their calendar day
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		key := date.Format("2006-01-02")
+		day, ok := days[key]
+		if !ok {
+			day = &calendarDay{date: date, aggregation: c.Aggregation}
+			days[key] = day
+		}
+		day.add(c.Data[i])
+	}
+	return days
+}
+
+// weekStartOf returns the first day of the week containing date
+func (c *HeatmapChart) weekStartOf(date time.Time) time.Time {
+	offset := (int(date.Weekday()) - int(c.WeekStart) + 7) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// weeksBetween returns the number of week columns needed to show from through to
+func (c *HeatmapChart) weeksBetween(from, to time.Time) int {
+	first := c.weekStartOf(from)
+	last := c.weekStartOf(to).AddDate(0, 0, 6)
+	totalDays := int(math.Round(last.Sub(first).Hours()/24)) + 1
+	return totalDays / 7
+}
+
+// renderMatrix draws the heatmap as a categorical grid of rows and columns
+func (c *HeatmapChart) renderMatrix(svg *strings.Builder) {
+	rows := len(c.Matrix)
+	cols := 0
+	for _, row := range c.Matrix {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if rows == 0 || cols == 0 {
+		return
+	}
+
+	// Collect all values for color scaling, skipping missing values
+	var values []float64
+	for _, row := range c.Matrix {
+		for _, v := range row {
+			if !IsMissing(v) {
+				values = append(values, v)
+			}
+		}
+	}
+	minVal, maxVal := c.valueRange(values)
+
+	// Reserve space for row labels on the left and column labels on top
+	rowLabelWidth := 0
+	for _, label := range c.RowLabels {
+		if w := int(math.Ceil(c.textWidth(label, "tick"))) + 10; w > rowLabelWidth {
+			rowLabelWidth = w
+		}
+	}
+	colLabelWidth := 0.0
+	for _, label := range c.ColumnLabels {
+		colLabelWidth = math.Max(colLabelWidth, c.textWidth(label, "tick"))
+	}
+
+	legendHeight := 0
+	if c.ShowLegend {
+		legendHeight = 40
+	}
+
+	availableWidth := c.Width - c.Margin.Left - c.Margin.Right - rowLabelWidth
+	cellWidth := (availableWidth - (cols-1)*c.CellSpacing) / cols
+
+	// Rotate column labels when they don't fit above their cell
+	rotateLabels := colLabelWidth > float64(cellWidth)
+	colLabelHeight := 20
+	if rotateLabels {
+		colLabelHeight = int(colLabelWidth*0.71) + 10
+	}
+
+	availableHeight := c.Height - c.Margin.Top - c.Margin.Bottom - colLabelHeight - legendHeight
+	cellHeight := (availableHeight - (rows-1)*c.CellSpacing) / rows
+	cellWidth = int(math.Max(3, float64(cellWidth)))
+	cellHeight = int(math.Max(3, float64(cellHeight)))
+
+	startX := c.Margin.Left + rowLabelWidth
+	startY := c.Margin.Top + colLabelHeight
+
+	// Column labels
+	for j := 0; j < cols && j < len(c.ColumnLabels); j++ {
+		labelX := startX + j*(cellWidth+c.CellSpacing) + cellWidth/2
+		if rotateLabels {
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="start" transform="rotate(-45 %d %d)"%s>%s</text>`,
+				labelX, startY-5, labelX, startY-5, c.textFill(), escapeText(c.ColumnLabels[j])))
+		} else {
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="middle"%s>%s</text>`,
+				labelX, startY-5, c.textFill(), escapeText(c.ColumnLabels[j])))
+		}
+	}
+
+	valueFontSize := int(math.Min(12, float64(cellHeight)*0.5))
+
+	for i, row := range c.Matrix {
+		rowY := startY + i*(cellHeight+c.CellSpacing)
+
+		// Row label
+		if i < len(c.RowLabels) {
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="end"%s>%s</text>`,
+				startX-5, rowY+cellHeight/2+4, c.textFill(), escapeText(c.RowLabels[i])))
+		}
+
+		for j, value := range row {
+			if IsMissing(value) {
+				continue // Missing values leave the cell empty
+			}
+			cellX := startX + j*(cellWidth+c.CellSpacing)
+			color := c.cellColor(value, minVal, maxVal)
+
+			rowLabel, colLabel := "", ""
+			if i < len(c.RowLabels) {
+				rowLabel = c.RowLabels[i]
+			}
+			if j < len(c.ColumnLabels) {
+				colLabel = c.ColumnLabels[j]
+			}
+
+			fields := markFields{series: colLabel, label: rowLabel, value: value, percent: Missing()}
+			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"%s%s><title>%s</title></rect>`,
+				cellX, rowY, cellWidth, cellHeight, c.CellRounding, c.CellRounding, color, c.dataAttributes(fields), c.markClass(),
+				escapeText(c.cellTooltip(fields, fmt.Sprintf("%s, %s: %v", rowLabel, colLabel, value)))))
+
+			// Value text with a color that stays readable on the cell
+			if c.ShowValues && valueFontSize >= 6 {
+				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-label" style="font-size: %dpx" text-anchor="middle" fill="%s">%s</text>`,
+					cellX+cellWidth/2, rowY+cellHeight/2+valueFontSize/3, valueFontSize, contrastTextColor(color),
+					fmt.Sprintf(c.ValueFormat, value)))
+			}
+		}
+	}
+
+	// Add legend
+	if c.ShowLegend {
+		legendCellSize := int(math.Min(15, float64(cellHeight)))
+		c.renderLegend(svg, c.Margin.Left, startY+rows*(cellHeight+c.CellSpacing)+15, legendCellSize, minVal, maxVal)
+	}
+}
+
+// valueRange returns the min and max values used for color scaling
+func (c *HeatmapChart) valueRange(values []float64) (minVal, maxVal float64) {
+	maxVal = c.MaxValue
+	minVal = c.MinValue
 
 	// Initialize min/max for auto-scaling
 	if maxVal <= 0 || minVal == 0 {
 		// Auto-scale
-		if len(c.Data) > 0 {
+		if len(values) > 0 {
 			// Initialize with first value
 			if maxVal <= 0 {
-				maxVal = c.Data[0]
+				maxVal = values[0]
 			}
 			if minVal == 0 {
-				minVal = c.Data[0]
+				minVal = values[0]
 			}
-			
+
 			// Find actual min/max
-			for _, v := range c.Data {
+			for _, v := range values {
 				if v > maxVal {
 					maxVal = v
 				}
@@ -1657,201 +2013,155 @@ func (c *HeatmapChart) Render() string {
 		}
 	}
 
-	// Starting position for the grid
-	startX := c.Margin.Left + dayLabelWidth // Space for day labels
-	startY := c.Margin.Top + 50             // Space for title and month labels
+	return minVal, maxVal
+}
 
-	// Draw day labels (using single-letter labels or user-defined labels)
-	if len(c.DayLabels) == 7 {
-		// Use user-defined labels if they've specified exactly 7 labels (one for each day)
-		for i, label := range c.DayLabels {
-			labelY := startY + i*(cellSize+c.CellSpacing) + cellSize/2 + 5
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="end" fill="var(--chart-text)">%s</text>`,
-					startX-5, labelY, label))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="end">%s</text>`,
-					startX-5, labelY, label))
-			}
-		}
+// scaleDomain returns the value range covered by the continuous color scale
+func (c *HeatmapChart) scaleDomain(minVal, maxVal float64) (lo, hi float64) {
+	if c.ColorScale.Diverging {
+		// Diverging scales are symmetric around zero
+		m := math.Max(math.Abs(minVal), math.Abs(maxVal))
+		lo, hi = -m, m
 	} else {
-		// Default to single-letter day labels
-		dayLetters := []string{"S", "M", "T", "W", "T", "F", "S"} // Sunday to Saturday
-		for i, letter := range dayLetters {
-			labelY := startY + i*(cellSize+c.CellSpacing) + cellSize/2 + 5
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="end" fill="var(--chart-text)">%s</text>`,
-					startX-5, labelY, letter))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="end">%s</text>`,
-					startX-5, labelY, letter))
-			}
-		}
+		// Sequential scales span the data, so a narrow range still uses the whole scale
+		lo, hi = minVal, maxVal
+	}
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// cellColor maps a value onto the positive or negative color scale
+func (c *HeatmapChart) cellColor(value, minVal, maxVal float64) string {
+	if c.ColorScale != nil {
+		lo, hi := c.scaleDomain(minVal, maxVal)
+		return c.ColorScale.At((value - lo) / (hi - lo))
 	}
 
-	// Draw the heatmap grid
-	currentDate := firstSunday
-	for week := 0; week < totalWeeks; week++ {
-		// Check if we need to draw month label
-		if currentDate.Day() <= 7 {
-			// This is the first week of the month
-			monthLabel := c.MonthLabels[currentDate.Month()-1]
-			labelX := startX + week*(cellSize+c.CellSpacing) + cellSize/2
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="middle" fill="var(--chart-text)">%s</text>`,
-					labelX, startY-5, monthLabel))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="middle">%s</text>`,
-					labelX, startY-5, monthLabel))
-			}
-		}
+	var color string
 
-		for day := 0; day < 7; day++ {
-			dateStr := currentDate.Format(c.DateFormat)
-			value := 0.0
+	if c.SupportNegative && value < 0 {
+		// Handle negative values
+		if len(c.NegativeColors) > 0 {
+			// Only use negative colors if we have them
+			negValue := math.Abs(value)
+			negMaxVal := math.Abs(minVal)
+			colorIndex := 0
 
-			// Check if we have data for this date
-			if val, ok := dateMap[dateStr]; ok {
-				value = val
+			if negMaxVal > 0 {
+				// Scale value from 0 to len(negativeColors)-1
+				colorIndex = int(math.Min(float64(len(c.NegativeColors)-1),
+					math.Floor(negValue/negMaxVal*float64(len(c.NegativeColors)))))
 			}
 
-			// Calculate color based on value
-			var color string
-			
-			if c.SupportNegative && value < 0 {
-				// Handle negative values
-				if len(c.NegativeColors) > 0 {
-					// Only use negative colors if we have them
-					negValue := math.Abs(value)
-					negMaxVal := math.Abs(minVal)
-					colorIndex := 0
-					
-					if negMaxVal > 0 {
-						// Scale value from 0 to len(negativeColors)-1
-						colorIndex = int(math.Min(float64(len(c.NegativeColors)-1), 
-							math.Floor(negValue/negMaxVal*float64(len(c.NegativeColors)))))
-					}
-					
-					// Use the negative color scheme
-					if colorIndex < len(c.NegativeColors) && colorIndex >= 0 {
-						color = c.NegativeColors[colorIndex]
-					} else {
-						color = c.NegativeColors[0] // Default to lowest negative color
-					}
-				} else {
-					// Fallback if no negative colors defined
-					color = c.Colors[0] // Default to lowest color
-				}
+			// Use the negative color scheme
+			if colorIndex < len(c.NegativeColors) && colorIndex >= 0 {
+				color = c.NegativeColors[colorIndex]
 			} else {
-				// Handle zero or positive values
-				colorIndex := 0
-				if maxVal > 0 {
-					// Scale value from 0 to len(colors)-1
-					colorIndex = int(math.Min(float64(len(c.Colors)-1), 
-						math.Floor(value/maxVal*float64(len(c.Colors)))))
-				}
-				
-				if colorIndex < len(c.Colors) && colorIndex >= 0 {
-					color = c.Colors[colorIndex]
-				} else {
-					color = c.Colors[0] // Default to lowest color
-				}
+				color = c.NegativeColors[0] // Default to lowest negative color
 			}
+		} else {
+			// Fallback if no negative colors defined
+			color = c.Colors[0] // Default to lowest color
+		}
+	} else {
+		// Handle zero or positive values
+		colorIndex := 0
+		if maxVal > 0 {
+			// Scale value from 0 to len(colors)-1
+			colorIndex = int(math.Min(float64(len(c.Colors)-1),
+				math.Floor(value/maxVal*float64(len(c.Colors)))))
+		}
+
+		if colorIndex < len(c.Colors) && colorIndex >= 0 {
+			color = c.Colors[colorIndex]
+		} else {
+			color = c.Colors[0] // Default to lowest color
+		}
+	}
 
-			// Calculate cell position
-			cellX := startX + week*(cellSize+c.CellSpacing)
-			cellY := startY + day*(cellSize+c.CellSpacing)
+	return color
+}
 
-			// Draw cell
-			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s">`,
-				cellX, cellY, cellSize, cellSize, c.CellRounding, c.CellRounding, color))
+// renderLegend draws the color scale legend starting at the given position
+func (c *HeatmapChart) renderLegend(svg *strings.Builder, legendX, legendY, cellSize int, minVal, maxVal float64) {
+	if c.ColorScale != nil {
+		c.renderGradientLegend(svg, legendX, legendY, cellSize, minVal, maxVal)
+		return
+	}
 
-			// Add tooltip
-			svg.WriteString(fmt.Sprintf(`<title>%s: %v</title></rect>`, dateStr, value))
+	legendLabelY := legendY + cellSize/2 + 5
 
-			// Move to next day
-			currentDate = currentDate.AddDate(0, 0, 1)
+	// Check if we need to display separate legends for positive and negative values
+	if c.SupportNegative && minVal < 0 && len(c.NegativeColors) > 0 && maxVal > 0 {
+		// Draw negative values legend
+		negLegendX := legendX
+
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="start"%s>-</text>`,
+			negLegendX, legendLabelY, c.textFill()))
+
+		// Draw negative color scale (reversed so most intense is leftmost)
+		for i := len(c.NegativeColors) - 1; i >= 0; i-- {
+			cellX := negLegendX + 20 + (len(c.NegativeColors)-1-i)*(cellSize+c.CellSpacing)
+			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"/>`,
+				cellX, legendY, cellSize, cellSize, c.CellRounding, c.CellRounding, c.NegativeColors[i]))
 		}
-	}
 
-	// Add legend
-	if c.ShowLegend {
-		legendX := c.Margin.Left
-		legendY := startY + 7*(cellSize+c.CellSpacing) + 30
-		legendLabelY := legendY + cellSize/2 + 5
-		
-		// Check if we need to display separate legends for positive and negative values
-		if c.SupportNegative && minVal < 0 && len(c.NegativeColors) > 0 && maxVal > 0 {
-			// Draw negative values legend
-			negLegendX := legendX
-			
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start" fill="var(--chart-text)">-</text>`,
-					negLegendX, legendLabelY))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start">-</text>`,
-					negLegendX, legendLabelY))
-			}
-			
-			// Draw negative color scale (reversed so most intense is leftmost)
-			for i := len(c.NegativeColors) - 1; i >= 0; i-- {
-				cellX := negLegendX + 20 + (len(c.NegativeColors)-1-i)*(cellSize+c.CellSpacing)
-				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"/>`,
-					cellX, legendY, cellSize, cellSize, c.CellRounding, c.CellRounding, c.NegativeColors[i]))
-			}
-			
-			// Draw positive values legend (starting after negative legend)
-			posLegendX := negLegendX + 20 + len(c.NegativeColors)*(cellSize+c.CellSpacing) + 30
-			
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start" fill="var(--chart-text)">+</text>`,
-					posLegendX, legendLabelY))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start">+</text>`,
-					posLegendX, legendLabelY))
-			}
-			
-			// Draw positive color scale
-			for i, color := range c.Colors {
-				cellX := posLegendX + 20 + i*(cellSize+c.CellSpacing)
-				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"/>`,
-					cellX, legendY, cellSize, cellSize, c.CellRounding, c.CellRounding, color))
-			}
-		} else {
-			// Draw simple legend (just positive or just negative)
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start" fill="var(--chart-text)">Less</text>`,
-					legendX, legendLabelY))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start">Less</text>`,
-					legendX, legendLabelY))
-			}
-			
-			// Determine which color set to use based on the data
-			colorSet := c.Colors
-			if c.SupportNegative && maxVal <= 0 && minVal < 0 && len(c.NegativeColors) > 0 {
-				// If all values are negative, use the negative color set
-				colorSet = c.NegativeColors
-			}
+		// Draw positive values legend (starting after negative legend)
+		posLegendX := negLegendX + 20 + len(c.NegativeColors)*(cellSize+c.CellSpacing) + 30
 
-			for i, color := range colorSet {
-				cellX := legendX + 40 + i*(cellSize+c.CellSpacing)
-				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"/>`,
-					cellX, legendY, cellSize, cellSize, c.CellRounding, c.CellRounding, color))
-			}
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="start"%s>+</text>`,
+			posLegendX, legendLabelY, c.textFill()))
 
-			if c.DarkModeSupport {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start" fill="var(--chart-text)">More</text>`,
-					legendX+40+len(colorSet)*(cellSize+c.CellSpacing)+5, legendLabelY))
-			} else {
-				svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" font-family="Arial" font-size="10" text-anchor="start">More</text>`,
-					legendX+40+len(colorSet)*(cellSize+c.CellSpacing)+5, legendLabelY))
-			}
+		// Draw positive color scale
+		for i, color := range c.Colors {
+			cellX := posLegendX + 20 + i*(cellSize+c.CellSpacing)
+			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"/>`,
+				cellX, legendY, cellSize, cellSize, c.CellRounding, c.CellRounding, color))
 		}
+	} else {
+		// Draw simple legend (just positive or just negative)
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="start"%s>Less</text>`,
+			legendX, legendLabelY, c.textFill()))
+
+		// Determine which color set to use based on the data
+		colorSet := c.Colors
+		if c.SupportNegative && maxVal <= 0 && minVal < 0 && len(c.NegativeColors) > 0 {
+			// If all values are negative, use the negative color set
+			colorSet = c.NegativeColors
+		}
+
+		for i, color := range colorSet {
+			cellX := legendX + 40 + i*(cellSize+c.CellSpacing)
+			svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="%s"/>`,
+				cellX, legendY, cellSize, cellSize, c.CellRounding, c.CellRounding, color))
+		}
+
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="start"%s>More</text>`,
+			legendX+40+len(colorSet)*(cellSize+c.CellSpacing)+5, legendLabelY, c.textFill()))
 	}
+}
 
-	svg.WriteString("</svg>")
-	return svg.String()
+// renderGradientLegend draws a continuous gradient bar with tick labels
+func (c *HeatmapChart) renderGradientLegend(svg *strings.Builder, legendX, legendY, barHeight int, minVal, maxVal float64) {
+	lo, hi := c.scaleDomain(minVal, maxVal)
+	barWidth := int(math.Min(200, float64(c.Width-legendX-c.Margin.Right)))
+	if barWidth <= 0 {
+		return
+	}
+
+	svg.WriteString("<defs>" + c.ColorScale.linearGradient() + "</defs>")
+	svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" fill="url(#%s)"/>`,
+		legendX, legendY, barWidth, barHeight, c.CellRounding, c.CellRounding, c.ColorScale.gradientID()))
+
+	for _, tick := range niceTicks(lo, hi, 5) {
+		x := legendX + int((tick-lo)/(hi-lo)*float64(barWidth))
+		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+			x, legendY+barHeight, x, legendY+barHeight+3, c.axisColor(), c.gridWidth()))
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick" text-anchor="middle"%s>%g</text>`,
+			x, legendY+barHeight+13, c.textFill(), tick))
+	}
 }
 
 // EnableDarkModeSupport enables automatic adaptation between light and dark mode
@@ -1898,6 +2208,178 @@ func (chart *BaseChart) SetLightTheme(backgroundColor, textColor, axisColor, gri
 	return chart
 }
 
+// renderHeader writes the opening svg tag, the theme styles and the background
+func (chart *BaseChart) renderHeader(svg *strings.Builder) {
+	scope, typography := chart.typographyScope()
+	svg.WriteString(fmt.Sprintf(`<svg width="100%%" height="auto" viewBox="0 0 %d %d" preserveAspectRatio="xMidYMid meet" class="%s" xmlns="http://www.w3.org/2000/svg">`, chart.Width, chart.Height, scope))
+
+	if chart.DarkModeSupport {
+		svg.WriteString(fmt.Sprintf(`
+			<style>
+				:root {
+					--chart-bg: %s;
+					--chart-text: %s;
+					--chart-axis: %s;
+					--chart-grid: %s;
+				}
+				@media (prefers-color-scheme: dark) {
+					:root {
+						--chart-bg: %s;
+						--chart-text: %s;
+						--chart-axis: %s;
+						--chart-grid: %s;
+					}
+				}
+			</style>
+		`,
+			chart.LightTheme.BackgroundColor,
+			chart.LightTheme.TextColor,
+			chart.LightTheme.AxisColor,
+			chart.LightTheme.GridColor,
+			chart.DarkTheme.BackgroundColor,
+			chart.DarkTheme.TextColor,
+			chart.DarkTheme.AxisColor,
+			chart.DarkTheme.GridColor))
+
+		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="var(--chart-bg)"/>`, chart.Width, chart.Height))
+	} else {
+		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, chart.Width, chart.Height, chart.BackgroundColor))
+	}
+
+	// Fonts are declared once per text role instead of on every text element
+	svg.WriteString(typography)
+	svg.WriteString(chart.hoverStyle(scope))
+}
+
+// renderTitle writes the chart title if one is set and titles are shown
+func (chart *BaseChart) renderTitle(svg *strings.Builder) {
+	if chart.ShowTitle && chart.Title != "" {
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="30" text-anchor="middle" class="chart-title"%s>%s</text>`,
+			chart.Width/2, chart.textFill(), escapeText(chart.Title)))
+	}
+}
+
+// textFill returns the fill attribute for text elements; without dark mode it uses the light
+// theme's text color, and is empty when none is set
+func (chart *BaseChart) textFill() string {
+	if chart.DarkModeSupport {
+		return ` fill="var(--chart-text)"`
+	}
+	if chart.LightTheme.TextColor != "" {
+		return fmt.Sprintf(` fill="%s"`, chart.LightTheme.TextColor)
+	}
+	return ""
+}
+
+// axisColor returns the stroke color for axis lines
+func (chart *BaseChart) axisColor() string {
+	if chart.DarkModeSupport {
+		return "var(--chart-axis)"
+	}
+	if chart.LightTheme.AxisColor != "" {
+		return chart.LightTheme.AxisColor
+	}
+	return "black"
+}
+
+// gridColor returns the stroke color for grid lines
+func (chart *BaseChart) gridColor() string {
+	if chart.DarkModeSupport {
+		return "var(--chart-grid)"
+	}
+	if chart.LightTheme.GridColor != "" {
+		return chart.LightTheme.GridColor
+	}
+	return "#dddddd"
+}
+
+// seriesColor returns the color for the series at the given index
+func (chart *BaseChart) seriesColor(index int) string {
+	if index < len(chart.SeriesColors) {
+		return chart.SeriesColors[index]
+	} else if len(chart.Colors) > 0 {
+		return chart.Colors[index%len(chart.Colors)]
+	}
+	// Default colors if none specified
+	return autoPalette[index%len(autoPalette)]
+}
+
+// legendColumnWidth returns the width of a series legend without a reserved legend area:
+// the widest series name plus its color box, at most 150 pixels
+func (chart *BaseChart) legendColumnWidth() int {
+	widest := 0.0
+	for _, series := range chart.Series {
+		widest = math.Max(widest, chart.textWidth(series.Name, "legend"))
+	}
+	return int(math.Min(150, math.Ceil(widest)+30))
+}
+
+// renderLegendText draws a legend label, truncated with an ellipsis to fit maxWidth pixels
+// Truncated labels keep the full text in a tooltip
+func (chart *BaseChart) renderLegendText(svg *strings.Builder, x, y int, label string, maxWidth float64) {
+	display := chart.truncateText(label, "legend", maxWidth)
+	tooltip := ""
+	if display != label {
+		tooltip = "<title>" + escapeText(label) + "</title>"
+	}
+	svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-legend"%s>%s%s</text>`,
+		x, y, chart.textFill(), escapeText(display), tooltip))
+}
+
+// contrastTextColor returns black or white, whichever is more readable on the given background
+func contrastTextColor(background string) string {
+	color := strings.TrimSpace(background)
+	if strings.HasPrefix(color, "hsl") {
+		// Use the lightness component of hsl(h, s%, l%)
+		var h, sat, l float64
+		if _, err := fmt.Sscanf(color, "hsl(%f, %f%%, %f%%)", &h, &sat, &l); err == nil && l < 55 {
+			return "#ffffff"
+		}
+		return "#000000"
+	}
+
+	r, g, b, ok := parseHexColor(color)
+	if !ok {
+		return "#000000"
+	}
+
+	// Relative luminance as defined by WCAG
+	luminance := 0.2126*srgbToLinear(r) + 0.7152*srgbToLinear(g) + 0.0722*srgbToLinear(b)
+	if luminance < 0.179 {
+		return "#ffffff"
+	}
+	return "#000000"
+}
+
+// niceTicks returns roughly count evenly spaced round values covering lo to hi
+func niceTicks(lo, hi float64, count int) []float64 {
+	if hi <= lo || count < 2 {
+		return []float64{lo}
+	}
+	rawStep := (hi - lo) / float64(count-1)
+	magnitude := math.Pow(10, math.Floor(math.Log10(rawStep)))
+	step := magnitude
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*magnitude >= rawStep {
+			step = m * magnitude
+			break
+		}
+	}
+
+	// Round to the step's precision to avoid floating point noise in labels
+	scale := math.Pow(10, math.Max(0, 1-math.Floor(math.Log10(step))))
+	var ticks []float64
+	for i := math.Ceil(lo / step); i*step <= hi+step*1e-9; i++ {
+		ticks = append(ticks, math.Round(i*step*scale)/scale+0) // +0 turns -0 into 0
+	}
+	return ticks
+}
+
+// escapeText escapes user supplied text for use inside SVG elements and attributes
+func escapeText(s string) string {
+	return html.EscapeString(s)
+}
+
 // SetLegendWidth sets the width of the legend area as a percentage of the chart width
 func (chart *BaseChart) SetLegendWidth(percentage float64) *BaseChart {
 	if percentage < 0 {
@@ -1911,36 +2393,46 @@ func (chart *BaseChart) SetLegendWidth(percentage float64) *BaseChart {
 }
 
 // SetPalette sets the color palette mode for automatic color assignment
-// Valid options are "auto" and "gradient"
+// Valid options are "auto", "gradient" and the named palettes listed by PaletteNames,
+// such as "okabe-ito" or "tableau10". Add "+patterns" (e.g. "okabe-ito+patterns") or use
+// "patterns" on its own to also fill bars and slices with patterns
 func (chart *BaseChart) SetPalette(palette string) *BaseChart {
-	palette = strings.ToLower(palette)
-	if palette == "auto" || palette == "gradient" {
-		chart.Palette = palette
+	palette = strings.ToLower(strings.TrimSpace(palette))
+	name, patterns := palette, false
+	if base, ok := strings.CutSuffix(palette, "+patterns"); ok {
+		name, patterns = strings.TrimSpace(base), true
+	} else if palette == "patterns" {
+		name, patterns = "", true
+	}
+
+	if name == "auto" || name == "gradient" {
+		chart.Palette = name
 
 		// Generate automatic colors based on the palette
 		chart.generatePaletteColors()
+	} else if colors, ok := NamedPalette(name); ok {
+		// Named palettes cycle through a fixed list, so they work before data is set
+		chart.Palette = name
+		chart.Colors = colors
+		chart.SeriesColors = nil
+	} else if name != "" {
+		return chart
+	}
+	if patterns {
+		chart.Patterns = true
 	}
 	return chart
 }
 
+// EnablePatterns fills bars and pie slices with hatching, dots and other patterns
+// over their colors, so series stay distinguishable in grayscale or for color blind readers
+func (chart *BaseChart) EnablePatterns(enable bool) *BaseChart {
+	chart.Patterns = enable
+	return chart
+}
+
 // generatePaletteColors generates colors based on the selected palette
 func (chart *BaseChart) generatePaletteColors() {
-	// Define a set of vibrant, contrasting base colors for the "auto" palette
-	autoBaseColors := []string{
-		"#4285F4", // Google Blue
-		"#EA4335", // Google Red
-		"#FBBC05", // Google Yellow
-		"#34A853", // Google Green
-		"#8AB4F8", // Light Blue
-		"#F6AEA9", // Light Red
-		"#FDE293", // Light Yellow
-		"#A8DAB5", // Light Green
-		"#673AB7", // Purple
-		"#FF9800", // Orange
-		"#00BCD4", // Cyan
-		"#795548", // Brown
-	}
-
 	// Define base hues for gradient palette (in HSL degrees)
 	// Well-separated hues for distinct series
 	gradientBaseHues := []int{
@@ -1966,8 +2458,8 @@ func (chart *BaseChart) generatePaletteColors() {
 				colors := make([]string, numColors)
 
 				for i := 0; i < numColors; i++ {
-					colorIndex := i % len(autoBaseColors)
-					colors[i] = autoBaseColors[colorIndex]
+					colorIndex := i % len(autoPalette)
+					colors[i] = autoPalette[colorIndex]
 				}
 
 				chart.Colors = colors
@@ -1978,8 +2470,8 @@ func (chart *BaseChart) generatePaletteColors() {
 			seriesColors := make([]string, numSeries)
 
 			for i := 0; i < numSeries; i++ {
-				colorIndex := i % len(autoBaseColors)
-				seriesColors[i] = autoBaseColors[colorIndex]
+				colorIndex := i % len(autoPalette)
+				seriesColors[i] = autoPalette[colorIndex]
 			}
 
 			chart.SeriesColors = seriesColors
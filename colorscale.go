@@ -0,0 +1,182 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ColorScale maps a position between 0 and 1 onto a continuous color gradient
+// Colors are interpolated in the OKLab color space so perceived steps stay even
+type ColorScale struct {
+	Name      string
+	Stops     []string // Hex colors, evenly spaced from 0 to 1
+	Diverging bool     // Diverging scales are centered on zero
+}
+
+// namedColorScales contains the built-in scales, sampled from matplotlib and ColorBrewer
+var namedColorScales = map[string]ColorScale{
+	"viridis": {Name: "viridis", Stops: []string{"#440154", "#482878", "#3e4989", "#31688e", "#26828e", "#1f9e89", "#35b779", "#6ece58", "#b5de2b", "#fde725"}},
+	"magma":   {Name: "magma", Stops: []string{"#000004", "#180f3d", "#440f76", "#721f81", "#9e2f7f", "#cd4071", "#f1605d", "#fd9668", "#feca8d", "#fcfdbf"}},
+	"inferno": {Name: "inferno", Stops: []string{"#000004", "#1b0c41", "#4a0c6b", "#781c6d", "#a52c60", "#cf4446", "#ed6925", "#fb9b06", "#f7d13d", "#fcffa4"}},
+	"plasma":  {Name: "plasma", Stops: []string{"#0d0887", "#46039f", "#7201a8", "#9c179e", "#bd3786", "#d8576b", "#ed7953", "#fb9f3a", "#fdca26", "#f0f921"}},
+	"cividis": {Name: "cividis", Stops: []string{"#00224e", "#123570", "#3b496c", "#575d6d", "#707173", "#8a8779", "#a69d75", "#c4b56c", "#e4cf5b", "#fee838"}},
+	"blues":   {Name: "blues", Stops: []string{"#f7fbff", "#deebf7", "#c6dbef", "#9ecae1", "#6baed6", "#4292c6", "#2171b5", "#08519c", "#08306b"}},
+	"greens":  {Name: "greens", Stops: []string{"#f7fcf5", "#e5f5e0", "#c7e9c0", "#a1d99b", "#74c476", "#41ab5d", "#238b45", "#006d2c", "#00441b"}},
+	"rdbu":    {Name: "rdbu", Diverging: true, Stops: []string{"#67001f", "#b2182b", "#d6604d", "#f4a582", "#fddbc7", "#f7f7f7", "#d1e5f0", "#92c5de", "#4393c3", "#2166ac", "#053061"}},
+	"rdylbu":  {Name: "rdylbu", Diverging: true, Stops: []string{"#a50026", "#d73027", "#f46d43", "#fdae61", "#fee090", "#ffffbf", "#e0f3f8", "#abd9e9", "#74add1", "#4575b4", "#313695"}},
+	"piyg":    {Name: "piyg", Diverging: true, Stops: []string{"#8e0152", "#c51b7d", "#de77ae", "#f1b6da", "#fde0ef", "#f7f7f7", "#e6f5d0", "#b8e186", "#7fbc41", "#4d9221", "#276419"}},
+}
+
+// NamedColorScale returns a built-in color scale by name (case insensitive)
+func NamedColorScale(name string) (ColorScale, bool) {
+	scale, ok := namedColorScales[strings.ToLower(strings.TrimSpace(name))]
+	return scale, ok
+}
+
+// ColorScaleNames returns the names of the built-in color scales in alphabetical order
+func ColorScaleNames() []string {
+	names := make([]string, 0, len(namedColorScales))
+	for name := range namedColorScales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewColorScale creates a custom sequential color scale from evenly spaced hex color stops
+func NewColorScale(stops ...string) ColorScale {
+	return ColorScale{Name: "custom", Stops: stops}
+}
+
+// Validate reports an error unless the scale has at least two stops and every stop is a
+// #rgb or #rrggbb color, since other colors can't be interpolated
+func (s ColorScale) Validate() error {
+	if len(s.Stops) < 2 {
+		return fmt.Errorf("a color scale needs at least two stops")
+	}
+	for _, stop := range s.Stops {
+		if _, _, _, ok := parseHexColor(stop); !ok || !strings.HasPrefix(strings.TrimSpace(stop), "#") {
+			return fmt.Errorf("stop '%s' must be a #rrggbb color", stop)
+		}
+	}
+	return nil
+}
+
+// At returns the interpolated color at position t (clamped to 0-1)
+func (s ColorScale) At(t float64) string {
+	if len(s.Stops) == 0 {
+		return "#000000"
+	}
+	if len(s.Stops) == 1 || math.IsNaN(t) {
+		return s.Stops[0]
+	}
+	t = math.Max(0, math.Min(1, t))
+
+	// Find the surrounding stops
+	pos := t * float64(len(s.Stops)-1)
+	i := int(math.Floor(pos))
+	if i >= len(s.Stops)-1 {
+		return s.Stops[len(s.Stops)-1]
+	}
+	frac := pos - float64(i)
+
+	l1, a1, b1 := hexToOKLab(s.Stops[i])
+	l2, a2, b2 := hexToOKLab(s.Stops[i+1])
+	return okLabToHex(l1+(l2-l1)*frac, a1+(a2-a1)*frac, b1+(b2-b1)*frac)
+}
+
+// gradientID returns a stable element id for the scale's gradient definition
+// Identical scales share an id, which is safe when several charts are inlined in one page
+func (s ColorScale) gradientID() string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.Join(s.Stops, ",")))
+	return fmt.Sprintf("gosvgchart-scale-%08x", h.Sum32())
+}
+
+// linearGradient returns an SVG linearGradient definition running left to right
+func (s ColorScale) linearGradient() string {
+	var def strings.Builder
+	def.WriteString(fmt.Sprintf(`<linearGradient id="%s" x1="0" y1="0" x2="1" y2="0">`, s.gradientID()))
+	// Sample between the stops as well so the gradient follows the OKLab path
+	const samples = 20
+	for i := 0; i <= samples; i++ {
+		t := float64(i) / samples
+		def.WriteString(fmt.Sprintf(`<stop offset="%.2f" stop-color="%s"/>`, t, s.At(t)))
+	}
+	def.WriteString(`</linearGradient>`)
+	return def.String()
+}
+
+// parseHexColor parses #rgb or #rrggbb into 0-255 channel values
+func parseHexColor(color string) (r, g, b float64, ok bool) {
+	hex := strings.TrimPrefix(strings.TrimSpace(color), "#")
+	var ri, gi, bi int
+	switch len(hex) {
+	case 3:
+		if _, err := fmt.Sscanf(hex, "%1x%1x%1x", &ri, &gi, &bi); err != nil {
+			return 0, 0, 0, false
+		}
+		return float64(ri * 17), float64(gi * 17), float64(bi * 17), true
+	case 6:
+		if _, err := fmt.Sscanf(hex, "%2x%2x%2x", &ri, &gi, &bi); err != nil {
+			return 0, 0, 0, false
+		}
+		return float64(ri), float64(gi), float64(bi), true
+	}
+	return 0, 0, 0, false
+}
+
+// srgbToLinear converts an sRGB channel (0-255) to linear light (0-1)
+func srgbToLinear(v float64) float64 {
+	v /= 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB converts linear light (0-1) to an sRGB channel (0-255)
+func linearToSRGB(v float64) float64 {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return math.Max(0, math.Min(255, v*255))
+}
+
+// hexToOKLab converts a hex color to OKLab coordinates
+func hexToOKLab(color string) (l, a, b float64) {
+	r8, g8, b8, ok := parseHexColor(color)
+	if !ok {
+		return 0, 0, 0
+	}
+	r, g, bl := srgbToLinear(r8), srgbToLinear(g8), srgbToLinear(b8)
+
+	lc := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	mc := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	sc := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+
+	l = 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc
+	a = 1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc
+	b = 0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+	return l, a, b
+}
+
+// okLabToHex converts OKLab coordinates to a hex color
+func okLabToHex(l, a, b float64) string {
+	lc := l + 0.3963377774*a + 0.2158037573*b
+	mc := l - 0.1055613458*a - 0.0638541728*b
+	sc := l - 0.0894841775*a - 1.2914855480*b
+	lc, mc, sc = lc*lc*lc, mc*mc*mc, sc*sc*sc
+
+	r := 4.0767416621*lc - 3.3077115913*mc + 0.2309699292*sc
+	g := -1.2684380046*lc + 2.6097574011*mc - 0.3413193965*sc
+	bl := -0.0041960863*lc - 0.7034186147*mc + 1.7076147010*sc
+
+	return fmt.Sprintf("#%02x%02x%02x",
+		int(math.Round(linearToSRGB(r))), int(math.Round(linearToSRGB(g))), int(math.Round(linearToSRGB(bl))))
+}
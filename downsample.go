@@ -0,0 +1,137 @@
+package gosvgchart
+
+import "math"
+
+// Downsampling methods for line charts
+const (
+	DownsampleNone    = ""        // Draw every point
+	DownsampleLTTB    = "lttb"    // Largest-Triangle-Three-Buckets, keeps the visual shape with one point per pixel column
+	DownsampleMinMax  = "minmax"  // Lowest and highest value of each pixel column, keeps every spike
+	DownsampleAverage = "average" // Mean of each pixel column, smooths out noise
+)
+
+// defaultMarkerLimit is the number of points above which a downsampled series is drawn
+// without point markers
+const defaultMarkerLimit = 200
+
+// SetDownsampling reduces large series to about one point per pixel column of the plot
+// with "lttb", "minmax" or "average"; an empty method draws every point
+// Downsampling also sets a marker limit of 200 points unless a limit is already set
+func (c *LineChart) SetDownsampling(method string) *LineChart {
+	c.Downsample = method
+	if method != DownsampleNone && c.MarkerLimit == 0 {
+		c.MarkerLimit = defaultMarkerLimit
+	}
+	return c
+}
+
+// SetMarkerLimit sets the number of points above which a series is drawn without point markers
+// A limit of 0, the default without downsampling, always draws the markers when ShowPoints is on
+func (c *LineChart) SetMarkerLimit(limit int) *LineChart {
+	c.MarkerLimit = limit
+	return c
+}
+
+// samplePoint is a point of a line at a possibly fractional category index
+type samplePoint struct {
+	index float64
+	value float64
+}
+
+// downsample reduces a run of points to about one point per pixel column
+// pixelsPerIndex is the horizontal distance between neighboring categories
+func downsample(method string, points []samplePoint, pixelsPerIndex float64) []samplePoint {
+	if len(points) < 3 {
+		return points
+	}
+	column := func(p samplePoint) int {
+		return int(math.Floor(p.index * pixelsPerIndex))
+	}
+	columns := column(points[len(points)-1]) - column(points[0]) + 1
+	if len(points) <= columns {
+		return points
+	}
+
+	switch method {
+	case DownsampleLTTB:
+		return largestTriangleThreeBuckets(points, max(3, columns))
+	case DownsampleMinMax, DownsampleAverage:
+		var sampled []samplePoint
+		for start := 0; start < len(points); {
+			end := start + 1
+			for end < len(points) && column(points[end]) == column(points[start]) {
+				end++
+			}
+			bucket := points[start:end]
+			if method == DownsampleAverage {
+				sampled = append(sampled, averagePoint(bucket))
+			} else {
+				sampled = append(sampled, minMaxPoints(bucket)...)
+			}
+			start = end
+		}
+		return sampled
+	}
+	return points
+}
+
+// largestTriangleThreeBuckets keeps the first and last point and, from each of threshold-2
+// equal buckets in between, the point forming the largest triangle with the previously kept
+// point and the average of the next bucket
+func largestTriangleThreeBuckets(points []samplePoint, threshold int) []samplePoint {
+	if threshold >= len(points) {
+		return points
+	}
+	sampled := make([]samplePoint, 0, threshold)
+	sampled = append(sampled, points[0])
+	bucketSize := float64(len(points)-2) / float64(threshold-2)
+	previous := points[0]
+	for i := 0; i < threshold-2; i++ {
+		next := averagePoint(points[int(float64(i+1)*bucketSize)+1 : min(int(float64(i+2)*bucketSize)+1, len(points))])
+
+		from, to := int(float64(i)*bucketSize)+1, int(float64(i+1)*bucketSize)+1
+		selected, largest := points[from], -1.0
+		for _, p := range points[from:to] {
+			area := math.Abs((previous.index-next.index)*(p.value-previous.value) - (previous.index-p.index)*(next.value-previous.value))
+			if area > largest {
+				selected, largest = p, area
+			}
+		}
+		sampled = append(sampled, selected)
+		previous = selected
+	}
+	return append(sampled, points[len(points)-1])
+}
+
+// averagePoint returns the mean index and value of a bucket of points
+func averagePoint(bucket []samplePoint) samplePoint {
+	var mean samplePoint
+	for _, p := range bucket {
+		mean.index += p.index
+		mean.value += p.value
+	}
+	mean.index /= float64(len(bucket))
+	mean.value /= float64(len(bucket))
+	return mean
+}
+
+// minMaxPoints returns the lowest and highest point of a bucket in their original order
+func minMaxPoints(bucket []samplePoint) []samplePoint {
+	lo, hi := 0, 0
+	for i, p := range bucket {
+		if p.value < bucket[lo].value {
+			lo = i
+		}
+		if p.value > bucket[hi].value {
+			hi = i
+		}
+	}
+	switch {
+	case lo == hi:
+		return []samplePoint{bucket[lo]}
+	case lo < hi:
+		return []samplePoint{bucket[lo], bucket[hi]}
+	default:
+		return []samplePoint{bucket[hi], bucket[lo]}
+	}
+}
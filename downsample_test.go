@@ -0,0 +1,46 @@
+package gosvgchart
+
+import (
+	"math"
+	"testing"
+)
+
+// benchmarkSeries returns a noisy wave of n points
+func benchmarkSeries(n int) []float64 {
+	data := make([]float64, n)
+	for i := range data {
+		x := float64(i)
+		data[i] = 100 + 50*math.Sin(x/5000) + 10*math.Sin(x*7.3) + 5*math.Cos(x*1.9)
+	}
+	return data
+}
+
+// benchmarkLineChart renders a 100k point line chart, reporting the SVG size
+func benchmarkLineChart(b *testing.B, method string) {
+	data := benchmarkSeries(100000)
+	b.ReportAllocs()
+	var size int
+	for i := 0; i < b.N; i++ {
+		chart := NewLineChart()
+		chart.SetData(data)
+		chart.SetDownsampling(method)
+		size = len(chart.Render())
+	}
+	b.ReportMetric(float64(size), "svg-bytes")
+}
+
+func BenchmarkLineChart100kNone(b *testing.B) {
+	benchmarkLineChart(b, DownsampleNone)
+}
+
+func BenchmarkLineChart100kLTTB(b *testing.B) {
+	benchmarkLineChart(b, DownsampleLTTB)
+}
+
+func BenchmarkLineChart100kMinMax(b *testing.B) {
+	benchmarkLineChart(b, DownsampleMinMax)
+}
+
+func BenchmarkLineChart100kAverage(b *testing.B) {
+	benchmarkLineChart(b, DownsampleAverage)
+}
@@ -0,0 +1,132 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SetErrorBounds sets the lower and upper bound of each value of a series, drawn as
+// whiskers on bars and as a shaded band around lines
+// An empty series name sets the bounds of the single series data
+func (chart *BaseChart) SetErrorBounds(series string, lower, upper []float64) *BaseChart {
+	if series == "" && len(chart.Series) == 0 {
+		chart.DataLower, chart.DataUpper = lower, upper
+		return chart
+	}
+	for i := range chart.Series {
+		if chart.Series[i].Name == series {
+			chart.Series[i].Lower, chart.Series[i].Upper = lower, upper
+		}
+	}
+	return chart
+}
+
+// SetErrors sets symmetric ± errors for each value of a series
+func (chart *BaseChart) SetErrors(series string, errors []float64) *BaseChart {
+	data := chart.Data
+	if series != "" || len(chart.Series) > 0 {
+		data = nil
+		for _, s := range chart.Series {
+			if s.Name == series {
+				data = s.Data
+			}
+		}
+	}
+	lower := make([]float64, len(data))
+	upper := make([]float64, len(data))
+	for i, v := range data {
+		lower[i], upper[i] = v, v
+		if i < len(errors) {
+			lower[i], upper[i] = v-math.Abs(errors[i]), v+math.Abs(errors[i])
+		}
+	}
+	return chart.SetErrorBounds(series, lower, upper)
+}
+
+// errorBounds returns the values and bounds of a series, or of the single series data
+func (chart *BaseChart) errorBounds(series int) (data, lower, upper []float64) {
+	if len(chart.Series) == 0 {
+		return chart.Data, chart.DataLower, chart.DataUpper
+	}
+	s := chart.Series[series]
+	return s.Data, s.Lower, s.Upper
+}
+
+// boundsMax returns the largest upper bound, so the value scale can include the whiskers and bands
+func (chart *BaseChart) boundsMax() float64 {
+	var maxValue float64
+	for _, upper := range append([][]float64{chart.DataUpper}, seriesUppers(chart.Series)...) {
+		for _, v := range upper {
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+	return maxValue
+}
+
+// seriesUppers returns the upper bounds of each series
+func seriesUppers(series []Series) [][]float64 {
+	uppers := make([][]float64, len(series))
+	for i, s := range series {
+		uppers[i] = s.Upper
+	}
+	return uppers
+}
+
+// renderConfidenceBand draws a translucent band between the lower and upper bounds of a line series
+func (chart *BaseChart) renderConfidenceBand(svg *strings.Builder, plot plotArea, series int, color string) {
+	data, lower, upper := chart.errorBounds(series)
+	n := min(len(data), len(lower), len(upper))
+	if n == 0 || plot.maxValue <= 0 {
+		return
+	}
+	// The band breaks where a value or bound is missing
+	var segment []int
+	for i := 0; i <= n; i++ {
+		if i < n && !IsMissing(data[i]) && !IsMissing(lower[i]) && !IsMissing(upper[i]) {
+			segment = append(segment, i)
+			continue
+		}
+		if len(segment) == 0 {
+			continue
+		}
+		var path strings.Builder
+		for k, j := range segment {
+			command := "L"
+			if k == 0 {
+				command = "M"
+			}
+			path.WriteString(fmt.Sprintf("%s%.1f,%.1f ", command, plot.x(j), plot.y(math.Max(upper[j], 0))))
+		}
+		for k := len(segment) - 1; k >= 0; k-- {
+			j := segment[k]
+			path.WriteString(fmt.Sprintf("L%.1f,%.1f ", plot.x(j), plot.y(math.Max(lower[j], 0))))
+		}
+		svg.WriteString(fmt.Sprintf(`<path d="%sZ" fill="%s" fill-opacity="0.2" stroke="none"%s/>`,
+			path.String(), color, chart.dataAttributes(chart.markFields(series, -1))))
+		segment = nil
+	}
+}
+
+// renderErrorBars draws whiskers with caps from the lower to the upper bound of each bar in a series
+func (chart *BaseChart) renderErrorBars(svg *strings.Builder, plot plotArea, series int, capWidth float64) {
+	data, lower, upper := chart.errorBounds(series)
+	if plot.maxValue <= 0 {
+		return
+	}
+	scale := float64(plot.height) / plot.maxValue
+	for i := 0; i < min(len(data), len(lower), len(upper)); i++ {
+		if (lower[i] == data[i] && upper[i] == data[i]) || IsMissing(lower[i]) || IsMissing(upper[i]) {
+			continue
+		}
+		x, y, ok := plot.point(series, i)
+		if !ok {
+			continue
+		}
+		// Offsets from the bar top keep whiskers on stacked segments too
+		y0 := y + (data[i]-lower[i])*scale
+		y1 := y - (upper[i]-data[i])*scale
+		svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f V%.1f M%.1f,%.1f h%.1f M%.1f,%.1f h%.1f" stroke="%s" stroke-width="1.5" fill="none"/>`,
+			x, y0, y1, x-capWidth/2, y0, capWidth, x-capWidth/2, y1, capWidth, chart.axisColor()))
+	}
+}
@@ -0,0 +1,437 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// GanttChart implements a Gantt chart for project schedules
+type GanttChart struct {
+	BaseChart
+	Tasks       []GanttTask
+	DateFormat  string // Date format string used to parse task dates
+	BarHeight   int    // Height of each task bar in pixels (0 for auto)
+	BarRounding int    // Corner radius of task bars
+	LabelWidth  int    // Width reserved for task names in pixels (0 for auto)
+	ShowToday   bool   // Draw a marker at the current date, off by default so output doesn't depend on the day it is rendered
+	Today       string // Date used for the today marker (empty for the current date)
+}
+
+// GanttTask represents a single row of a Gantt chart
+type GanttTask struct {
+	Name      string
+	Start     string  // Start date, parsed with the chart's DateFormat
+	End       string  // End date, parsed with the chart's DateFormat
+	Progress  float64 // Completed fraction (0-1), negative when progress is not tracked
+	Milestone bool    // Milestones are drawn as a diamond at the start date
+}
+
+// ganttTickStep describes the spacing and label format of time axis ticks
+type ganttTickStep struct {
+	days   int
+	months int
+	layout string
+}
+
+// ganttTickSteps lists the candidate tick spacings from finest to coarsest
+var ganttTickSteps = []ganttTickStep{
+	{days: 1, layout: "Jan 2"},
+	{days: 2, layout: "Jan 2"},
+	{days: 7, layout: "Jan 2"},
+	{days: 14, layout: "Jan 2"},
+	{months: 1, layout: "Jan 2006"},
+	{months: 3, layout: "Jan 2006"},
+	{months: 6, layout: "Jan 2006"},
+	{months: 12, layout: "2006"},
+	{months: 24, layout: "2006"},
+	{months: 60, layout: "2006"},
+}
+
+// NewGanttChart creates a new Gantt chart with default settings
+func NewGanttChart() *GanttChart {
+	chart := &GanttChart{
+		BaseChart: BaseChart{
+			ChartType:       "gantt",
+			Width:           800,
+			Height:          400,
+			AutoHeight:      false,
+			ShowTitle:       true,
+			ShowLegend:      false,
+			BackgroundColor: "#ffffff",
+			DarkModeSupport: true, // Enable dark mode by default
+		},
+		DateFormat:  "2006-01-02",
+		BarHeight:   0, // 0 means auto-size from the row height
+		BarRounding: 3,
+		LabelWidth:  0, // 0 means auto-size from the task names
+	}
+
+	chart.Margin.Top = 50
+	chart.Margin.Right = 50
+	chart.Margin.Bottom = 50
+	chart.Margin.Left = 20
+
+	// Default colors
+	chart.Colors = []string{"#3498db", "#e74c3c", "#2ecc71", "#f39c12", "#9b59b6"}
+
+	// Set up default themes
+	chart.EnableDarkModeSupport(true)
+
+	return chart
+}
+
+// GanttChart methods to implement Chart interface
+
+// SetTitle sets the chart title
+func (c *GanttChart) SetTitle(title string) Chart {
+	c.Title = title
+	return c
+}
+
+// SetSize sets the chart dimensions in pixels
+func (c *GanttChart) SetSize(width, height int) Chart {
+	c.Width = width
+	c.Height = height
+	c.AutoHeight = false
+	return c
+}
+
+// SetAutoHeight enables automatic height calculation based on the number of tasks
+func (c *GanttChart) SetAutoHeight(auto bool) Chart {
+	c.AutoHeight = auto
+	return c
+}
+
+// SetData is not used by Gantt charts, tasks are added with AddTask
+func (c *GanttChart) SetData(data []float64) Chart {
+	c.Data = data
+	return c
+}
+
+// SetLabels is not used by Gantt charts, tasks are added with AddTask
+func (c *GanttChart) SetLabels(labels []string) Chart {
+	c.Labels = labels
+	return c
+}
+
+// SetColors sets the task bar colors as hex values
+func (c *GanttChart) SetColors(colors []string) Chart {
+	c.Colors = colors
+	return c
+}
+
+// AddSeries is not meaningful for Gantt charts
+// This implementation will replace the existing data with the new series
+func (c *GanttChart) AddSeries(name string, data []float64) Chart {
+	c.Data = data
+	return c
+}
+
+// SetSeriesColors sets the task bar colors
+// For Gantt charts, this is the same as SetColors
+func (c *GanttChart) SetSeriesColors(colors []string) Chart {
+	c.Colors = colors
+	return c
+}
+
+// SetLegendWidth sets the width of the legend area as a percentage of the chart width
+func (c *GanttChart) SetLegendWidth(percentage float64) Chart {
+	c.BaseChart.SetLegendWidth(percentage)
+	return c
+}
+
+// SetPalette sets the color palette mode for automatic color assignment
+func (c *GanttChart) SetPalette(palette string) Chart {
+	c.BaseChart.SetPalette(palette)
+	return c
+}
+
+// AddTask adds a task bar from start to end
+// Progress is the completed fraction (0-1); pass a negative value for tasks without progress tracking
+func (c *GanttChart) AddTask(name, start, end string, progress float64) *GanttChart {
+	c.Tasks = append(c.Tasks, GanttTask{Name: name, Start: start, End: end, Progress: progress})
+	return c
+}
+
+// AddMilestone adds a milestone drawn as a diamond on the given date
+func (c *GanttChart) AddMilestone(name, date string) *GanttChart {
+	c.Tasks = append(c.Tasks, GanttTask{Name: name, Start: date, End: date, Progress: -1, Milestone: true})
+	return c
+}
+
+// SetDateFormat sets the date format string (Go time format)
+func (c *GanttChart) SetDateFormat(format string) *GanttChart {
+	c.DateFormat = format
+	return c
+}
+
+// SetBarHeight sets the height of the task bars in pixels
+func (c *GanttChart) SetBarHeight(height int) *GanttChart {
+	c.BarHeight = height
+	return c
+}
+
+// SetBarRounding sets the corner radius of the task bars
+func (c *GanttChart) SetBarRounding(radius int) *GanttChart {
+	c.BarRounding = radius
+	return c
+}
+
+// SetLabelWidth sets the width reserved for task names in pixels
+func (c *GanttChart) SetLabelWidth(width int) *GanttChart {
+	c.LabelWidth = width
+	return c
+}
+
+// SetShowToday shows or hides the marker for the current date
+// The marker is off by default, since it makes the output change from day to day
+func (c *GanttChart) SetShowToday(show bool) *GanttChart {
+	c.ShowToday = show
+	return c
+}
+
+// SetToday shows the today marker at a fixed date, parsed with the chart's DateFormat
+func (c *GanttChart) SetToday(date string) *GanttChart {
+	c.Today = date
+	c.ShowToday = true
+	return c
+}
+
+// Render renders the Gantt chart to an SVG string
+func (c *GanttChart) Render() string {
+	var svg strings.Builder
+
+	// Apply auto-height if enabled
+	if c.AutoHeight {
+		// Use a fixed row height so every task stays readable
+		c.Height = c.Margin.Top + c.Margin.Bottom + len(c.Tasks)*30
+	}
+
+	c.renderHeader(&svg)
+	c.renderTitle(&svg)
+
+	// Parse task dates, skipping tasks that can't be parsed
+	type parsedTask struct {
+		GanttTask
+		index      int // Position in Tasks, which SetPointURL links by
+		start, end time.Time
+	}
+	var tasks []parsedTask
+	var minDate, maxDate time.Time
+	for index, task := range c.Tasks {
+		start, err := time.Parse(c.DateFormat, task.Start)
+		if err != nil {
+			continue
+		}
+		end := start
+		if !task.Milestone {
+			if end, err = time.Parse(c.DateFormat, task.End); err != nil {
+				continue
+			}
+			if end.Before(start) {
+				start, end = end, start
+			}
+		}
+		tasks = append(tasks, parsedTask{GanttTask: task, index: index, start: start, end: end})
+		if minDate.IsZero() || start.Before(minDate) {
+			minDate = start
+		}
+		if maxDate.IsZero() || end.After(maxDate) {
+			maxDate = end
+		}
+	}
+
+	// If no tasks, return empty SVG
+	if len(tasks) == 0 {
+		svg.WriteString("</svg>")
+		return svg.String()
+	}
+
+	// Pad the time range by a day on each side so bars don't touch the edges
+	minDate = minDate.AddDate(0, 0, -1)
+	maxDate = maxDate.AddDate(0, 0, 1)
+
+	// Reserve space for task names
+	labelWidth := c.LabelWidth
+	if labelWidth <= 0 {
+		longest := 0.0
+		for _, task := range tasks {
+			longest = math.Max(longest, c.textWidth(task.Name, "label"))
+		}
+		labelWidth = int(math.Min(math.Ceil(longest)+15, float64(c.Width)*0.4))
+	}
+
+	chartLeft := c.Margin.Left + labelWidth
+	chartWidth := c.Width - chartLeft - c.Margin.Right
+	chartTop := c.Margin.Top
+	chartHeight := c.Height - c.Margin.Top - c.Margin.Bottom
+	chartBottom := chartTop + chartHeight
+	if chartWidth <= 0 || chartHeight <= 0 {
+		svg.WriteString("</svg>")
+		return svg.String()
+	}
+
+	span := maxDate.Sub(minDate).Hours()
+	xFor := func(t time.Time) float64 {
+		return float64(chartLeft) + t.Sub(minDate).Hours()/span*float64(chartWidth)
+	}
+
+	// Draw the time axis with vertical grid lines
+	pxPerDay := float64(chartWidth) / (span / 24)
+	step := ganttTickSteps[len(ganttTickSteps)-1]
+	for _, candidate := range ganttTickSteps {
+		stepDays := float64(candidate.days) + float64(candidate.months)*30.44
+		if stepDays*pxPerDay >= 60 {
+			step = candidate
+			break
+		}
+	}
+	for _, tick := range ganttTicks(minDate, maxDate, step) {
+		x := xFor(tick)
+		svg.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="%g"/>`,
+			x, chartTop, x, chartBottom, c.gridColor(), c.gridWidth()))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" text-anchor="middle" class="chart-tick"%s>%s</text>`,
+			x, chartBottom+15, c.textFill(), tick.Format(step.layout)))
+	}
+
+	// Draw the axis line
+	svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"/>`,
+		chartLeft, chartBottom, chartLeft+chartWidth, chartBottom, c.axisColor(), c.axisWidth()))
+
+	// Row geometry
+	rowHeight := float64(chartHeight) / float64(len(tasks))
+	barHeight := rowHeight * 0.6
+	if c.BarHeight > 0 {
+		barHeight = math.Min(float64(c.BarHeight), rowHeight)
+	}
+
+	for i, task := range tasks {
+		rowCenter := float64(chartTop) + (float64(i)+0.5)*rowHeight
+		color := c.seriesColor(i)
+
+		// Task name, truncated to the label column with the full name as a tooltip
+		name := c.truncateText(task.Name, "label", float64(labelWidth-10))
+		nameTooltip := ""
+		if name != task.Name {
+			nameTooltip = "<title>" + escapeText(task.Name) + "</title>"
+		}
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" class="chart-label"%s>%s%s</text>`,
+			chartLeft-8, rowCenter+4, c.textFill(), escapeText(name), nameTooltip))
+
+		if task.Milestone {
+			// Milestones are drawn as a diamond centered on the date
+			x := xFor(task.start)
+			r := barHeight / 2
+			fields := c.taskFields(task.GanttTask, task.start, task.end)
+			linked := c.openLink(&svg, c.markURL(0, task.index), fields, "")
+			svg.WriteString(fmt.Sprintf(`<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"%s%s><title>%s</title></polygon>`,
+				x, rowCenter-r, x+r, rowCenter, x, rowCenter+r, x-r, rowCenter,
+				color, c.markClass(), c.dataAttributes(fields), escapeText(c.cellTooltip(fields, fmt.Sprintf("%s: %s", task.Name, task.start.Format(c.DateFormat))))))
+			if linked {
+				svg.WriteString("</a>")
+			}
+			continue
+		}
+
+		x0 := xFor(task.start)
+		barWidth := math.Max(1, xFor(task.end)-x0)
+		barY := rowCenter - barHeight/2
+
+		// The bars of a task share one group, so its tooltip and hover highlight cover both
+		fields := c.taskFields(task.GanttTask, task.start, task.end)
+		tooltip := fmt.Sprintf("%s: %s – %s", task.Name, task.start.Format(c.DateFormat), task.end.Format(c.DateFormat))
+		if task.Progress >= 0 {
+			tooltip += fmt.Sprintf(" (%.0f%%)", math.Min(1, task.Progress)*100)
+		}
+		linked := c.openLink(&svg, c.markURL(0, task.index), fields, "")
+		svg.WriteString(fmt.Sprintf(`<g%s%s><title>%s</title>`, c.markClass(), c.dataAttributes(fields), escapeText(c.cellTooltip(fields, tooltip))))
+		if task.Progress >= 0 {
+			progress := math.Min(1, task.Progress)
+
+			// Faded bar for the full duration with the completed part on top
+			svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%d" ry="%d" fill="%s" fill-opacity="0.35"/>`,
+				x0, barY, barWidth, barHeight, c.BarRounding, c.BarRounding, color))
+			if progress > 0 {
+				svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%d" ry="%d" fill="%s"/>`,
+					x0, barY, barWidth*progress, barHeight, c.BarRounding, c.BarRounding, color))
+			}
+		} else {
+			svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%d" ry="%d" fill="%s"/>`,
+				x0, barY, barWidth, barHeight, c.BarRounding, c.BarRounding, color))
+		}
+		svg.WriteString("</g>")
+		if linked {
+			svg.WriteString("</a>")
+		}
+	}
+
+	// Draw the today marker if it falls inside the time range
+	if c.ShowToday {
+		today := time.Now()
+		if c.Today != "" {
+			if parsed, err := time.Parse(c.DateFormat, c.Today); err == nil {
+				today = parsed
+			}
+		}
+		if !today.Before(minDate) && !today.After(maxDate) {
+			x := xFor(today)
+			svg.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#e74c3c" stroke-width="2" stroke-dasharray="4,3"/>`,
+				x, chartTop-5, x, chartBottom))
+			svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" text-anchor="middle" class="chart-tick" fill="#e74c3c">Today</text>`,
+				x, chartTop-8))
+		}
+	}
+
+	svg.WriteString("</svg>")
+	return svg.String()
+}
+
+// taskFields returns what tooltips show about a task: its name as the series and label,
+// its progress in percent as the value, and the {task}, {start}, {end} and {progress} fields
+func (c *GanttChart) taskFields(task GanttTask, start, end time.Time) markFields {
+	fields := markFields{series: task.Name, label: task.Name, value: Missing(), percent: Missing()}
+	progress := ""
+	if task.Progress >= 0 && !task.Milestone {
+		fields.value = math.Min(1, task.Progress) * 100
+		progress = fmt.Sprintf("%.0f%%", fields.value)
+	}
+	fields.extra = [][2]string{
+		{"task", task.Name},
+		{"start", start.Format(c.DateFormat)},
+		{"end", end.Format(c.DateFormat)},
+		{"progress", progress},
+	}
+	return fields
+}
+
+// ganttTicks returns the tick dates between start and end for the given step
+func ganttTicks(start, end time.Time, step ganttTickStep) []time.Time {
+	var tick time.Time
+	if step.months > 0 {
+		// Align to the first day of a month that is a multiple of the step
+		month := (int(start.Month()) - 1) / step.months * step.months
+		if step.months >= 12 {
+			month = 0
+		}
+		tick = time.Date(start.Year(), time.Month(month+1), 1, 0, 0, 0, 0, start.Location())
+		if step.months >= 12 {
+			tick = time.Date(start.Year()/(step.months/12)*(step.months/12), 1, 1, 0, 0, 0, 0, start.Location())
+		}
+	} else {
+		tick = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+		if step.days%7 == 0 {
+			// Weekly ticks start on Mondays
+			tick = tick.AddDate(0, 0, -((int(tick.Weekday()) + 6) % 7))
+		}
+	}
+
+	var ticks []time.Time
+	for ; !tick.After(end); tick = tick.AddDate(0, step.months, step.days) {
+		if !tick.Before(start) {
+			ticks = append(ticks, tick)
+		}
+	}
+	return ticks
+}
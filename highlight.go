@@ -0,0 +1,160 @@
+package gosvgchart
+
+import "fmt"
+
+// Highlight rule kinds
+const (
+	HighlightAbove = "above" // Values above a threshold
+	HighlightBelow = "below" // Values below a threshold
+	HighlightLabel = "label" // Values at a category label
+)
+
+// PointStyle overrides how a single bar, point or slice is drawn, so it stands out
+// without moving it into a series of its own
+type PointStyle struct {
+	Color    string  // Fill of the bar, slice or marker, the series color when empty
+	Opacity  float64 // Opacity from 0 to 1, unchanged when zero
+	Emphasis bool    // Outlines bars and slices, and draws a larger marker on lines
+}
+
+// HighlightRule styles every point that matches a condition, such as values above a limit
+type HighlightRule struct {
+	Kind   string  // One of the Highlight* kinds
+	Value  float64 // Threshold of an above or below rule
+	Label  string  // Category of a label rule
+	Series string  // Series the rule applies to, every series when empty
+	Style  PointStyle
+}
+
+// SetPointStyle overrides the style of the point at index in a series
+// An empty series name styles a point of the single series data
+func (chart *BaseChart) SetPointStyle(series string, index int, style PointStyle) *BaseChart {
+	if series == "" && len(chart.Series) == 0 {
+		if chart.DataPoints == nil {
+			chart.DataPoints = make(map[int]PointStyle)
+		}
+		chart.DataPoints[index] = style
+		return chart
+	}
+	for i := range chart.Series {
+		if chart.Series[i].Name == series {
+			if chart.Series[i].Points == nil {
+				chart.Series[i].Points = make(map[int]PointStyle)
+			}
+			chart.Series[i].Points[index] = style
+		}
+	}
+	return chart
+}
+
+// AddHighlight adds a conditional coloring rule, later rules override earlier ones
+// and per-point styles override both
+func (chart *BaseChart) AddHighlight(rule HighlightRule) *BaseChart {
+	chart.Highlights = append(chart.Highlights, rule)
+	return chart
+}
+
+// HighlightAbove colors the values above a threshold, such as out-of-range readings
+func (chart *BaseChart) HighlightAbove(value float64, color string) *BaseChart {
+	return chart.AddHighlight(HighlightRule{Kind: HighlightAbove, Value: value, Style: PointStyle{Color: color}})
+}
+
+// HighlightBelow colors the values below a threshold
+func (chart *BaseChart) HighlightBelow(value float64, color string) *BaseChart {
+	return chart.AddHighlight(HighlightRule{Kind: HighlightBelow, Value: value, Style: PointStyle{Color: color}})
+}
+
+// HighlightLabel colors and emphasizes the values at a category label, such as the current month
+func (chart *BaseChart) HighlightLabel(label, color string) *BaseChart {
+	return chart.AddHighlight(HighlightRule{Kind: HighlightLabel, Label: label, Style: PointStyle{Color: color, Emphasis: true}})
+}
+
+// matches reports whether a value at a category label meets the rule's condition
+func (rule HighlightRule) matches(value float64, label string) bool {
+	switch rule.Kind {
+	case HighlightAbove:
+		return value > rule.Value
+	case HighlightBelow:
+		return value < rule.Value
+	case HighlightLabel:
+		return label == rule.Label
+	}
+	return false
+}
+
+// pointStyle returns the style of the point at index i of a series, merged from the
+// matching rules and the point's own override, and whether any of them applies
+func (chart *BaseChart) pointStyle(series, i int) (PointStyle, bool) {
+	data, points, name := chart.Data, chart.DataPoints, ""
+	if series < len(chart.Series) {
+		data, points, name = chart.Series[series].Data, chart.Series[series].Points, chart.Series[series].Name
+	}
+	if i < 0 || i >= len(data) || IsMissing(data[i]) {
+		return PointStyle{}, false
+	}
+	label := ""
+	if i < len(chart.Labels) {
+		label = chart.Labels[i]
+	}
+
+	var style PointStyle
+	matched := false
+	for _, rule := range chart.Highlights {
+		if (rule.Series == "" || rule.Series == name) && rule.matches(data[i], label) {
+			style = style.merge(rule.Style)
+			matched = true
+		}
+	}
+	if override, ok := points[i]; ok {
+		style = style.merge(override)
+		matched = true
+	}
+	return style, matched
+}
+
+// merge returns the style with the fields set in other overriding its own
+func (style PointStyle) merge(other PointStyle) PointStyle {
+	if other.Color != "" {
+		style.Color = other.Color
+	}
+	if other.Opacity > 0 {
+		style.Opacity = other.Opacity
+	}
+	style.Emphasis = style.Emphasis || other.Emphasis
+	return style
+}
+
+// colorOr returns the point color, or color when the style sets none
+func (style PointStyle) colorOr(color string) string {
+	if style.Color != "" {
+		return style.Color
+	}
+	return color
+}
+
+// attributes returns the opacity and emphasis outline attributes of a bar or slice
+func (style PointStyle) attributes(outline string) string {
+	attrs := ""
+	if style.Opacity > 0 && style.Opacity < 1 {
+		attrs += fmt.Sprintf(` opacity="%g"`, style.Opacity)
+	}
+	if style.Emphasis {
+		attrs += fmt.Sprintf(` stroke="%s" stroke-width="2"`, outline)
+	}
+	return attrs
+}
+
+// marker returns the line marker style of a highlighted point, which is drawn even
+// when the series has no markers
+func (style PointStyle) marker(series SeriesStyle) SeriesStyle {
+	if series.Marker == MarkerNone {
+		series.Marker = MarkerCircle
+	}
+	if style.Opacity > 0 && style.Opacity <= 1 {
+		series.Opacity = style.Opacity
+	}
+	if style.Emphasis {
+		series.MarkerSize *= 1.6
+	}
+	return series
+}
@@ -0,0 +1,188 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetInteractive embeds a small script in line and bar charts: clicking a legend entry hides
+// or shows its series, and line charts add a crosshair with the values of every series at the
+// hovered label, zoom on the X range with the mouse wheel, panning by dragging and a reset by
+// double-clicking. The script works on the data attributes of the marks, which interactive
+// charts always carry. Charts are script-free unless this is enabled.
+func (chart *BaseChart) SetInteractive(enabled bool) *BaseChart {
+	chart.Interactive = enabled
+	return chart
+}
+
+// beginLegendItem starts the legend entry of a series, which toggles the series in interactive charts
+func (chart *BaseChart) beginLegendItem(svg *strings.Builder, series string) {
+	if chart.Interactive {
+		svg.WriteString(fmt.Sprintf(`<g class="chart-legend-item" data-series="%s" style="cursor: pointer">`, escapeText(series)))
+	}
+}
+
+// endLegendItem closes the legend entry started by beginLegendItem
+func (chart *BaseChart) endLegendItem(svg *strings.Builder) {
+	if chart.Interactive {
+		svg.WriteString("</g>")
+	}
+}
+
+// zoomClass returns the class attribute of lines and areas that follow the X zoom of
+// interactive line charts without carrying data attributes, such as trendlines
+func (chart *BaseChart) zoomClass() string {
+	if chart.Interactive {
+		return ` class="chart-zoom"`
+	}
+	return ""
+}
+
+// renderScript embeds the interaction script for the plot area of a chart, with the crosshair
+// and X zoom for line charts
+func (chart *BaseChart) renderScript(svg *strings.Builder, plot plotArea, line bool) {
+	if !chart.Interactive {
+		return
+	}
+	svg.WriteString(fmt.Sprintf(`<script><![CDATA[(%s)(document.currentScript.parentNode, %d, %d, %d, %d, %t);]]></script>`,
+		interactiveScript, plot.left, plot.top, plot.width, plot.height, line))
+}
+
+// interactiveScript toggles series from the legend and, on line charts, draws a crosshair
+// and zooms and pans the X range by transforming lines and moving points and X labels
+// It takes the chart's svg element, the plot area and whether the chart is a line chart
+const interactiveScript = `function (svg, L, T, W, H, line) {
+  var NS = 'http://www.w3.org/2000/svg';
+  var each = function (selector, f) { Array.prototype.forEach.call(svg.querySelectorAll(selector), f); };
+  var make = function (tag, attrs, parent) {
+    var el = document.createElementNS(NS, tag);
+    for (var k in attrs) el.setAttribute(k, attrs[k]);
+    if (parent) parent.appendChild(el);
+    return el;
+  };
+  var center = function (el) { var b = el.getBBox(); return b.x + b.width / 2; };
+  var hidden = {};
+
+  // Legend entries hide and show the marks of their series, with the link or group around them
+  each('.chart-legend-item', function (item) {
+    item.addEventListener('click', function () {
+      var name = item.getAttribute('data-series');
+      hidden[name] = !hidden[name];
+      item.style.opacity = hidden[name] ? 0.4 : '';
+      each('[data-series]', function (el) {
+        if (el.getAttribute('data-series') !== name || el.classList.contains('chart-legend-item')) return;
+        var mark = el.parentNode.classList && el.parentNode.classList.contains('chart-mark') ? el.parentNode : el;
+        mark.style.display = hidden[name] ? 'none' : '';
+      });
+    });
+  });
+  if (!line) return;
+
+  // Lines and areas are scaled along X inside a clip of the plot area, points and labels move
+  var clip = 'gosvgchart-clip-' + Math.random().toString(36).slice(2);
+  make('rect', { x: L, y: 0, width: W, height: '100%' }, make('clipPath', { id: clip }, make('defs', {}, svg)));
+  var paths = [];
+  each('path[data-series]:not([data-value]), .chart-zoom', function (path) {
+    var g = make('g', { 'clip-path': 'url(#' + clip + ')' });
+    path.parentNode.insertBefore(g, path);
+    g.appendChild(path);
+    path.style.vectorEffect = 'non-scaling-stroke';
+    paths.push(path);
+  });
+  var points = [], moving = [];
+  each('[data-value]', function (el) { points.push({ el: el, x: center(el) }); });
+  each('.chart-xlabels text', function (el) { moving.push({ el: el, x: center(el) }); });
+  moving = moving.concat(points).map(function (m) { m.transform = m.el.getAttribute('transform') || ''; return m; });
+
+  // The visible X range in unzoomed coordinates
+  var start = L, span = W;
+  var toX = function (x) { return L + (x - start) * W / span; };
+  var apply = function () {
+    var s = W / span;
+    paths.forEach(function (path) { path.setAttribute('transform', 'matrix(' + s + ',0,0,1,' + (L - start * s) + ',0)'); });
+    moving.forEach(function (m) {
+      var x = toX(m.x);
+      m.el.setAttribute('transform', 'translate(' + (x - m.x) + ',0) ' + m.transform);
+      m.el.style.visibility = x < L - 1 || x > L + W + 1 ? 'hidden' : '';
+    });
+  };
+  var limit = function () {
+    span = Math.max(W / 50, Math.min(W, span));
+    start = Math.max(L, Math.min(L + W - span, start));
+  };
+  var mouse = function (e) {
+    var p = svg.createSVGPoint();
+    p.x = e.clientX;
+    p.y = e.clientY;
+    return p.matrixTransform(svg.getScreenCTM().inverse());
+  };
+
+  svg.addEventListener('wheel', function (e) {
+    var p = mouse(e);
+    if (p.x < L || p.x > L + W) return;
+    e.preventDefault();
+    var anchor = start + (p.x - L) * span / W;
+    span *= e.deltaY < 0 ? 0.8 : 1.25;
+    start = anchor - (p.x - L) * span / W;
+    limit();
+    apply();
+    crosshair(p);
+  }, { passive: false });
+  var drag = null;
+  svg.addEventListener('mousedown', function (e) { drag = mouse(e).x; });
+  window.addEventListener('mouseup', function () { drag = null; });
+  svg.addEventListener('dblclick', function () { start = L; span = W; apply(); });
+
+  // Crosshair with the values of every visible series at the nearest label
+  var columns = [], byX = {};
+  points.forEach(function (p) {
+    var key = Math.round(p.x * 10);
+    if (!byX[key]) columns.push(byX[key] = { label: p.el.getAttribute('data-label') || '', x: p.x, items: [] });
+    byX[key].items.push(p.el);
+  });
+  var cross = make('g', { 'pointer-events': 'none', style: 'display: none' }, svg);
+  var rule = make('line', { y1: T, y2: T + H, stroke: 'var(--chart-axis, #888)', 'stroke-dasharray': '3,3' }, cross);
+  var box = make('rect', { rx: 3, fill: 'var(--chart-bg, white)', stroke: 'var(--chart-axis, #888)', opacity: 0.95 }, cross);
+  var readout = make('g', {}, cross);
+  var crosshair = function (p) {
+    var best = null;
+    columns.forEach(function (c) {
+      var visible = c.items.filter(function (el) { return !hidden[el.getAttribute('data-series')]; });
+      var x = toX(c.x);
+      if (visible.length && x >= L - 1 && x <= L + W + 1 && (!best || Math.abs(x - p.x) < Math.abs(best.x - p.x))) {
+        best = { x: x, label: c.label, items: visible };
+      }
+    });
+    if (!best || p.x < L || p.x > L + W || p.y < T || p.y > T + H) { cross.style.display = 'none'; return; }
+    cross.style.display = '';
+    rule.setAttribute('x1', best.x);
+    rule.setAttribute('x2', best.x);
+    readout.textContent = '';
+    [best.label].concat(best.items.map(function (el) {
+      var series = el.getAttribute('data-series');
+      return (series ? series + ': ' : '') + Number(el.getAttribute('data-value')).toLocaleString();
+    })).forEach(function (text, k) {
+      var t = make('text', { x: 0, y: 16 * (k + 1), class: 'chart-legend', fill: 'var(--chart-text, black)' }, readout);
+      if (k > 0) t.setAttribute('fill', best.items[k - 1].getAttribute('fill') || best.items[k - 1].getAttribute('stroke'));
+      t.textContent = text;
+    });
+    var b = readout.getBBox();
+    var x = best.x + 10 + b.width + 12 > L + W ? best.x - 10 - b.width - 12 : best.x + 10;
+    readout.setAttribute('transform', 'translate(' + (x + 6) + ',' + (T + 4) + ')');
+    box.setAttribute('x', x);
+    box.setAttribute('y', T + 4);
+    box.setAttribute('width', b.width + 12);
+    box.setAttribute('height', b.height + 8);
+  };
+  svg.addEventListener('mousemove', function (e) {
+    var p = mouse(e);
+    if (drag !== null) {
+      start -= (p.x - drag) * span / W;
+      drag = p.x;
+      limit();
+      apply();
+    }
+    crosshair(p);
+  });
+  svg.addEventListener('mouseleave', function () { cross.style.display = 'none'; });
+}`
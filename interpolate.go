@@ -0,0 +1,141 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Interpolation modes for the lines of a line chart
+const (
+	InterpolateLinear     = "linear"      // Straight lines between points
+	InterpolateMonotone   = "monotone"    // Monotone cubic (Fritsch–Carlson), smooth without overshooting the data
+	InterpolateCatmullRom = "catmull-rom" // Catmull-Rom spline through every point, loosened or tightened by Tension
+	InterpolateStepBefore = "step-before" // Steps up or down at the start of each interval
+	InterpolateStepAfter  = "step-after"  // Holds each value until the next point
+	InterpolateStepMiddle = "step-middle" // Steps halfway between points
+)
+
+// SetInterpolation sets how lines are drawn between points: "linear" (default), "monotone",
+// "catmull-rom", "step-before", "step-after" or "step-middle"
+func (c *LineChart) SetInterpolation(mode string) *LineChart {
+	c.Interpolation = mode
+	return c
+}
+
+// SetTension sets the tension of Catmull-Rom lines, from 0 (the standard spline) to 1 (straight lines)
+func (c *LineChart) SetTension(tension float64) *LineChart {
+	c.Tension = tension
+	return c
+}
+
+// interpolation returns the interpolation mode of the lines
+// SetSmooth(true) without an explicit mode draws monotone cubic lines
+func (c *LineChart) interpolation() string {
+	if c.Interpolation != "" {
+		return c.Interpolation
+	}
+	if c.Smooth {
+		return InterpolateMonotone
+	}
+	return InterpolateLinear
+}
+
+// linePath returns the path data of a line through points ordered by x
+func linePath(points [][2]float64, mode string, tension float64) string {
+	var path strings.Builder
+	if len(points) == 0 {
+		return ""
+	}
+	path.WriteString(fmt.Sprintf("M%.1f,%.1f", points[0][0], points[0][1]))
+	lineTo := func(x, y float64) {
+		path.WriteString(fmt.Sprintf(" L%.1f,%.1f", x, y))
+	}
+	curveTo := func(x1, y1, x2, y2, x, y float64) {
+		path.WriteString(fmt.Sprintf(" C%.1f,%.1f %.1f,%.1f %.1f,%.1f", x1, y1, x2, y2, x, y))
+	}
+
+	switch mode {
+	case InterpolateMonotone:
+		slopes := monotoneSlopes(points)
+		for k := 1; k < len(points); k++ {
+			p0, p1 := points[k-1], points[k]
+			dx := (p1[0] - p0[0]) / 3
+			if dx == 0 {
+				lineTo(p1[0], p1[1])
+				continue
+			}
+			curveTo(p0[0]+dx, p0[1]+slopes[k-1]*dx, p1[0]-dx, p1[1]-slopes[k]*dx, p1[0], p1[1])
+		}
+	case InterpolateCatmullRom:
+		scale := (1 - math.Max(0, math.Min(1, tension))) / 6
+		for k := 1; k < len(points); k++ {
+			// The end points stand in for the missing neighbors of the first and last interval
+			p0, p1, p2, p3 := points[max(k-2, 0)], points[k-1], points[k], points[min(k+1, len(points)-1)]
+			curveTo(p1[0]+(p2[0]-p0[0])*scale, p1[1]+(p2[1]-p0[1])*scale,
+				p2[0]-(p3[0]-p1[0])*scale, p2[1]-(p3[1]-p1[1])*scale, p2[0], p2[1])
+		}
+	case InterpolateStepBefore:
+		for k := 1; k < len(points); k++ {
+			lineTo(points[k-1][0], points[k][1])
+			lineTo(points[k][0], points[k][1])
+		}
+	case InterpolateStepAfter:
+		for k := 1; k < len(points); k++ {
+			lineTo(points[k][0], points[k-1][1])
+			lineTo(points[k][0], points[k][1])
+		}
+	case InterpolateStepMiddle:
+		for k := 1; k < len(points); k++ {
+			middle := (points[k-1][0] + points[k][0]) / 2
+			lineTo(middle, points[k-1][1])
+			lineTo(middle, points[k][1])
+			lineTo(points[k][0], points[k][1])
+		}
+	default:
+		for _, p := range points[1:] {
+			lineTo(p[0], p[1])
+		}
+	}
+	return path.String()
+}
+
+// monotoneSlopes returns the tangent at each point of a monotone cubic line (Fritsch–Carlson),
+// limited so the curve never overshoots between two points
+func monotoneSlopes(points [][2]float64) []float64 {
+	n := len(points)
+	slopes := make([]float64, n)
+	if n < 2 {
+		return slopes
+	}
+
+	// Slopes of the secants between neighboring points
+	secants := make([]float64, n-1)
+	for k := range secants {
+		if dx := points[k+1][0] - points[k][0]; dx != 0 {
+			secants[k] = (points[k+1][1] - points[k][1]) / dx
+		}
+	}
+
+	// Start from the mean of the neighboring secants, flat at local extremes
+	slopes[0], slopes[n-1] = secants[0], secants[n-2]
+	for k := 1; k < n-1; k++ {
+		if secants[k-1]*secants[k] > 0 {
+			slopes[k] = (secants[k-1] + secants[k]) / 2
+		}
+	}
+
+	// Shrink tangents that would make an interval overshoot
+	for k, secant := range secants {
+		if secant == 0 {
+			slopes[k], slopes[k+1] = 0, 0
+			continue
+		}
+		a, b := slopes[k]/secant, slopes[k+1]/secant
+		if s := a*a + b*b; s > 9 {
+			t := 3 / math.Sqrt(s)
+			slopes[k], slopes[k+1] = t*a*secant, t*b*secant
+		}
+	}
+	return slopes
+}
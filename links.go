@@ -0,0 +1,217 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SetSeriesURL links every bar, point or slice of a series to a URL
+// The URL may contain {series}, {label} and {value}, filled in for each mark, such as
+// "/sales?month={label}"; an empty series name links the single series data
+// With an empty series name, Sankey diagrams link every flow and Gantt charts every task
+// Only relative URLs and http, https and mailto links are drawn, see ValidateLinkURL
+func (chart *BaseChart) SetSeriesURL(series, url string) *BaseChart {
+	if series == "" && len(chart.Series) == 0 {
+		chart.DataURL = url
+		return chart
+	}
+	for i := range chart.Series {
+		if chart.Series[i].Name == series {
+			chart.Series[i].URL = url
+		}
+	}
+	return chart
+}
+
+// SetPointURL links the bar, point or slice at index in a series to a URL, overriding the series URL
+// An empty series name links a point of the single series data, the flow at index of a Sankey
+// diagram (flows added with AddFlow first, then those from labels) or the task at index of a Gantt chart
+func (chart *BaseChart) SetPointURL(series string, index int, url string) *BaseChart {
+	if series == "" && len(chart.Series) == 0 {
+		if chart.DataURLs == nil {
+			chart.DataURLs = make(map[int]string)
+		}
+		chart.DataURLs[index] = url
+		return chart
+	}
+	for i := range chart.Series {
+		if chart.Series[i].Name == series {
+			if chart.Series[i].URLs == nil {
+				chart.Series[i].URLs = make(map[int]string)
+			}
+			chart.Series[i].URLs[index] = url
+		}
+	}
+	return chart
+}
+
+// SetLinkTarget sets the target of links, such as "_blank" or "_top"; links open in the
+// same frame when it is empty
+func (chart *BaseChart) SetLinkTarget(target string) *BaseChart {
+	chart.LinkTarget = target
+	return chart
+}
+
+// SetDataAttributes adds data-series, data-label and data-value attributes to every bar,
+// point, slice, heatmap cell, Sankey flow and node and Gantt task, so scripts can find marks
+// without parsing their geometry; flows and tasks also get the fields their tooltips show,
+// such as data-source, data-target, data-start and data-end
+func (chart *BaseChart) SetDataAttributes(enabled bool) *BaseChart {
+	chart.DataAttributes = enabled
+	return chart
+}
+
+// ValidateLinkURL reports an error unless a URL is relative or uses the http, https or mailto
+// scheme, so chart definitions can't smuggle javascript: or data: links into a page
+func ValidateLinkURL(link string) error {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("not a valid URL")
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "", "http", "https", "mailto":
+		return nil
+	}
+	return fmt.Errorf("scheme '%s' is not allowed - links must be relative or use http, https or mailto", parsed.Scheme)
+}
+
+// linkHref fills in the URL template of a mark, escaping the fields for the path or the query
+// string they fall in, and returns false when the result is not a safe link
+func linkHref(template string, fields markFields) (string, bool) {
+	path, query, hasQuery := strings.Cut(template, "?")
+	href := fields.fill(path, url.PathEscape)
+	if hasQuery {
+		href += "?" + fields.fill(query, url.QueryEscape)
+	}
+	return href, ValidateLinkURL(href) == nil
+}
+
+// markURL returns the URL template of the mark at index i of a series: the point's own URL,
+// or the series URL; i is -1 for a mark that stands for no single value, such as "Other"
+func (chart *BaseChart) markURL(series, i int) string {
+	urls, seriesURL := chart.DataURLs, chart.DataURL
+	if series < len(chart.Series) {
+		urls, seriesURL = chart.Series[series].URLs, chart.Series[series].URL
+	}
+	if url, ok := urls[i]; ok && i >= 0 {
+		return url
+	}
+	return seriesURL
+}
+
+// markFields holds what links, tooltips and data attributes can show about a mark
+type markFields struct {
+	series, label string
+	value         float64     // Missing for a mark that stands for a whole series, such as a line
+	percent       float64     // Share of the whole, missing unless the mark is a pie slice
+	extra         [][2]string // Names and values of chart specific fields, such as the source of a flow
+}
+
+// markFields returns the series name, category label and value of the mark at index i of a series
+func (chart *BaseChart) markFields(series, i int) markFields {
+	fields := markFields{value: Missing(), percent: Missing()}
+	data := chart.Data
+	if series < len(chart.Series) {
+		fields.series, data = chart.Series[series].Name, chart.Series[series].Data
+	}
+	if i >= 0 && i < len(chart.Labels) {
+		fields.label = chart.Labels[i]
+	}
+	if i >= 0 && i < len(data) {
+		fields.value = data[i]
+	}
+	return fields
+}
+
+// beginMark starts the mark at index i of a series: it opens a link when the mark has a URL,
+// or a group for its tooltip, and returns the data attributes for the mark's element
+// Every beginMark is followed by an endMark once the mark's elements are written
+func (chart *BaseChart) beginMark(svg *strings.Builder, series, i int) string {
+	return chart.openMark(svg, chart.markURL(series, i), chart.markFields(series, i))
+}
+
+// endMark closes the link or group opened by beginMark, if any
+func (chart *BaseChart) endMark(svg *strings.Builder, series, i int) {
+	chart.closeMark(svg, chart.markURL(series, i), chart.markFields(series, i))
+}
+
+// openLink opens a link to the URL template filled in for a mark, with the given extra
+// attributes, and reports whether it did; it opens none when the template is empty or
+// gives an unsafe link
+func (chart *BaseChart) openLink(svg *strings.Builder, template string, fields markFields, attrs string) bool {
+	href, ok := linkHref(template, fields)
+	if template == "" || !ok {
+		return false
+	}
+	target := ""
+	if chart.LinkTarget != "" {
+		target = fmt.Sprintf(` target="%s"`, escapeText(chart.LinkTarget))
+	}
+	svg.WriteString(fmt.Sprintf(`<a href="%s"%s%s>`, escapeText(href), target, attrs))
+	return true
+}
+
+// closeMark closes the link or group opened by openMark for the same URL template and fields
+func (chart *BaseChart) closeMark(svg *strings.Builder, template string, fields markFields) {
+	if _, ok := linkHref(template, fields); ok && template != "" {
+		svg.WriteString("</a>")
+	} else if chart.groupsMarks() {
+		svg.WriteString("</g>")
+	}
+}
+
+// openMark opens a link to the URL template filled in for a mark when the template is set
+// and gives a safe link, or a group when the mark has a tooltip or hover highlighting, and
+// returns the data attributes of the mark
+func (chart *BaseChart) openMark(svg *strings.Builder, template string, fields markFields) string {
+	if chart.openLink(svg, template, fields, chart.markClass()) {
+		// The link carries the tooltip and hover class
+	} else if chart.groupsMarks() {
+		svg.WriteString(fmt.Sprintf(`<g%s>`, chart.markClass()))
+	}
+	if tooltip := chart.tooltipText(fields); tooltip != "" {
+		svg.WriteString("<title>" + escapeText(tooltip) + "</title>")
+	}
+	return chart.dataAttributes(fields)
+}
+
+// groupsMarks reports whether marks without a link are wrapped in a group, for their
+// tooltip, hover highlighting or the interaction script
+func (chart *BaseChart) groupsMarks() bool {
+	return chart.Tooltip != "" || chart.HoverHighlight || chart.Interactive
+}
+
+// dataAttributes returns the data-series, data-label and data-value attributes of a mark, and
+// data attributes for chart specific fields such as data-source, when data attributes are
+// enabled or the chart is interactive, leaving out the empty ones
+func (chart *BaseChart) dataAttributes(fields markFields) string {
+	if !chart.DataAttributes && !chart.Interactive {
+		return ""
+	}
+	var attrs strings.Builder
+	if fields.series != "" {
+		attrs.WriteString(fmt.Sprintf(` data-series="%s"`, escapeText(fields.series)))
+	}
+	if fields.label != "" {
+		attrs.WriteString(fmt.Sprintf(` data-label="%s"`, escapeText(fields.label)))
+	}
+	if !IsMissing(fields.value) {
+		attrs.WriteString(fmt.Sprintf(` data-value="%s"`, formatDataValue(fields.value)))
+	}
+	for _, extra := range fields.extra {
+		if extra[1] != "" {
+			attrs.WriteString(fmt.Sprintf(` data-%s="%s"`, extra[0], escapeText(extra[1])))
+		}
+	}
+	return attrs.String()
+}
+
+// formatDataValue formats a value for URLs and data attributes, in full precision without exponents
+func formatDataValue(value float64) string {
+	if IsMissing(value) {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
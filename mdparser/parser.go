@@ -2,9 +2,11 @@ package mdparser
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/riclib/gosvgchart"
 )
@@ -23,15 +25,91 @@ type ChartDefinition struct {
 	SeriesColors    []string
 	Stacked         bool
 	LegendWidth     float64
-	Palette         string // "auto" or "gradient"
+	Palette         string // "auto", "gradient" or a named palette, optionally with "+patterns"
 	SupportNegative bool
 	NegativeColors  []string
+	DateFormat      string
+	Today           string // Date of the Gantt today marker, "now" for the current date
+	Tasks           []TaskDefinition
+	SparkMode       string // "line" or "bar" for sparklines
+	ShowMin         bool
+	ShowMax         bool
+	ShowLast        bool
+	Fill            bool
+	Band            []float64 // Lower and upper bound of a reference band
+	ShowValues      bool
+	ColorScale      string   // Name of a built-in continuous color scale
+	ColorScaleStops []string // Custom continuous color scale stops
+	WeekStart       time.Weekday
+	Aggregation     string // How calendar values on the same day combine
+	SplitYears      bool
+	Patterns        bool   // Fill bars, slices and areas with patterns
+	Theme           string // Name of a built-in or registered theme
+	FontFamily      string // CSS font stack for all chart text
+	FontSizes       []int  // Title, label, tick and legend font sizes
+	XLabels         string // X-axis label strategy for line and bar charts
+	XLabelStep      int    // Draw every Nth x-axis label
+	XAxisTitle      string
+	YAxisTitle      string
+	XAxisUnit       string // Suffix for x-axis labels
+	YAxisUnit       string // Suffix for y-axis ticks and bar values
+	Annotations     []gosvgchart.Annotation
+	Overlays        []gosvgchart.Overlay // Trendlines and moving averages, an empty series means every series
+	FitStats        bool
+	DataLower       []float64                         // Lower bounds of the single series data
+	DataUpper       []float64                         // Upper bounds of the single series data
+	Gaps            string                            // How lines handle missing values
+	Downsample      string                            // Downsampling method for large line series
+	Interpolation   string                            // How lines are drawn between points
+	Tension         float64                           // Tension of Catmull-Rom lines
+	SeriesStyles    map[string]gosvgchart.SeriesStyle // Line styles by series name, "" for the single series data
+	StackMode       string                            // How bar series combine, overrides Stacked when set
+	StackGroups     map[string][]string               // Series names of each bar stack group
+	PieLabels       string                            // Where pie slice labels go
+	OtherThreshold  float64                           // Pie slices below this percentage are grouped into "Other"
+	OtherTopN       int                               // Pie slices beyond the largest n are grouped into "Other"
+	OtherLabel      string                            // Label of the grouped pie slice
+	SortSlices      string                            // Pie slice order
+	StartAngle      float64                           // Pie start angle in degrees clockwise from 3 o'clock
+	Explode         []string                          // Labels of pie slices pulled out of the pie
+	Highlights      []gosvgchart.HighlightRule        // Conditional coloring rules
+	URLs            []string                          // Link templates, each optionally prefixed with "Series ="
+	LinkTarget      string
+	DataAttributes  bool   // Add data-series, data-label and data-value attributes to marks
+	Tooltip         string // Tooltip template of every mark
+	Hover           bool   // Dim the other marks while one is hovered
+	Interactive     bool   // Embed the crosshair, legend toggle and zoom script
+}
+
+// addData appends a value with its bounds to the single series data
+func (chartDef *ChartDefinition) addData(value, lower, upper float64) {
+	chartDef.Data = append(chartDef.Data, value)
+	chartDef.DataLower = append(chartDef.DataLower, lower)
+	chartDef.DataUpper = append(chartDef.DataUpper, upper)
 }
 
 // SeriesDefinition represents a data series in a chart
 type SeriesDefinition struct {
-	Name string
-	Data []float64
+	Name  string
+	Data  []float64
+	Lower []float64 // Lower bound of each value, the value itself without error bounds
+	Upper []float64 // Upper bound of each value
+}
+
+// add appends a value with its bounds to the series
+func (s *SeriesDefinition) add(value, lower, upper float64) {
+	s.Data = append(s.Data, value)
+	s.Lower = append(s.Lower, lower)
+	s.Upper = append(s.Upper, upper)
+}
+
+// TaskDefinition represents a task or milestone row in a Gantt chart
+type TaskDefinition struct {
+	Name      string
+	Start     string
+	End       string
+	Progress  float64 // Negative when no progress column was given
+	Milestone bool
 }
 
 // ParseMarkdownChart parses a chart specification from markdown text format
@@ -113,6 +191,8 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 	chartDef.AutoHeight = false
 	chartDef.Stacked = false
 	chartDef.Palette = "" // Empty means no palette specified
+	chartDef.DateFormat = "2006-01-02"
+	chartDef.SplitYears = true
 
 	if len(lines) < 3 {
 		return chartDef, fmt.Errorf("chart format invalid - too few lines. Need at least chart type, configuration, and data sections")
@@ -127,10 +207,21 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 		"bar": true, "barchart": true,
 		"pie": true, "piechart": true,
 		"heatmap": true, "heatmapchart": true,
+		"sankey": true, "sankeychart": true,
+		"gantt": true, "ganttchart": true,
+		"sparkline": true, "sparklinechart": true,
 	}
 
 	if !validTypes[chartDef.ChartType] {
-		return chartDef, fmt.Errorf("unknown chart type '%s'. Must be one of: linechart, barchart, piechart, heatmapchart", chartDef.ChartType)
+		return chartDef, fmt.Errorf("unknown chart type '%s'. Must be one of: linechart, barchart, piechart, heatmapchart, sankeychart, ganttchart, sparkline", chartDef.ChartType)
+	}
+	isGantt := chartDef.ChartType == "gantt" || chartDef.ChartType == "ganttchart"
+
+	// Sparklines are inline-sized by default
+	if chartDef.ChartType == "sparkline" || chartDef.ChartType == "sparklinechart" {
+		chartDef.Width = 100
+		chartDef.Height = 24
+		chartDef.Title = ""
 	}
 
 	// Parse configuration and data
@@ -138,6 +229,7 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 	var foundDataSection bool = false
 	var dataErrors []string
 	var configErrors []string
+	var todayLine int // Line of a today date, checked against the date format after the configuration
 
 	// Variables for series support
 	var currentSeries string
@@ -214,13 +306,30 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 						valueStr := strings.TrimSpace(parts[j])
 
 						// Add data to the corresponding series
-						if val, err := strconv.ParseFloat(valueStr, 64); err == nil {
-							chartDef.Series[j-1].Data = append(chartDef.Series[j-1].Data, val)
+						if val, lower, upper, err := parseDataCell(valueStr); err == nil {
+							chartDef.Series[j-1].add(val, lower, upper)
 						} else {
 							dataErrors = append(dataErrors, fmt.Sprintf("line %d: '%s' is not a valid number for series '%s'",
 								i+1, valueStr, seriesNames[j-1]))
 						}
 					}
+
+					// Series without a cell in a short row are missing a value
+					for j := len(parts); j <= len(seriesNames); j++ {
+						missing := gosvgchart.Missing()
+						chartDef.Series[j-1].add(missing, missing, missing)
+					}
+				}
+				continue
+			}
+
+			// Gantt rows are "Task | Start | End | Progress" or "Milestone | Date"
+			if isGantt {
+				task, err := parseTaskRow(line, chartDef.DateFormat)
+				if err != nil {
+					dataErrors = append(dataErrors, fmt.Sprintf("line %d: %v", i+1, err))
+				} else {
+					chartDef.Tasks = append(chartDef.Tasks, task)
 				}
 				continue
 			}
@@ -266,8 +375,8 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 					}
 
 					// Add data to series
-					if val, err := strconv.ParseFloat(valueStr, 64); err == nil {
-						chartDef.Series[seriesIndex].Data = append(chartDef.Series[seriesIndex].Data, val)
+					if val, lower, upper, err := parseDataCell(valueStr); err == nil {
+						chartDef.Series[seriesIndex].add(val, lower, upper)
 					} else {
 						dataErrors = append(dataErrors, fmt.Sprintf("line %d: '%s' is not a valid number", i+1, valueStr))
 					}
@@ -275,8 +384,8 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 					// Legacy single series
 					chartDef.Labels = append(chartDef.Labels, label)
 
-					if val, err := strconv.ParseFloat(valueStr, 64); err == nil {
-						chartDef.Data = append(chartDef.Data, val)
+					if val, lower, upper, err := parseDataCell(valueStr); err == nil {
+						chartDef.addData(val, lower, upper)
 					} else {
 						dataErrors = append(dataErrors, fmt.Sprintf("line %d: '%s' is not a valid number", i+1, valueStr))
 					}
@@ -299,15 +408,15 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 					}
 
 					// Add data to series
-					if val, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err == nil {
-						chartDef.Series[seriesIndex].Data = append(chartDef.Series[seriesIndex].Data, val)
+					if val, lower, upper, err := parseDataCell(strings.TrimSpace(parts[0])); err == nil {
+						chartDef.Series[seriesIndex].add(val, lower, upper)
 					} else {
 						dataErrors = append(dataErrors, fmt.Sprintf("line %d: '%s' is not a valid number", i+1, parts[0]))
 					}
 				} else {
 					// Legacy single series
-					if val, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err == nil {
-						chartDef.Data = append(chartDef.Data, val)
+					if val, lower, upper, err := parseDataCell(strings.TrimSpace(parts[0])); err == nil {
+						chartDef.addData(val, lower, upper)
 					} else {
 						dataErrors = append(dataErrors, fmt.Sprintf("line %d: '%s' is not a valid number", i+1, parts[0]))
 					}
@@ -368,6 +477,25 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 				} else {
 					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid stacked value '%s' - must be true/false, yes/no, or 1/0", i+1, value))
 				}
+			case "stackmode":
+				value = strings.ToLower(value)
+				switch value {
+				case gosvgchart.StackGrouped, gosvgchart.StackStacked, gosvgchart.StackPercent, gosvgchart.StackGroupedStacked:
+					chartDef.StackMode = value
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid stackmode value '%s' - must be grouped, stacked, percent, or grouped-stacked", i+1, value))
+				}
+			case "stackgroup":
+				group, series, ok := strings.Cut(value, "=")
+				if !ok || strings.TrimSpace(group) == "" || len(parseList(series)) == 0 {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid stackgroup value '%s' - expected 'group = series, series'", i+1, value))
+					continue
+				}
+				if chartDef.StackGroups == nil {
+					chartDef.StackGroups = make(map[string][]string)
+				}
+				group = strings.TrimSpace(group)
+				chartDef.StackGroups[group] = append(chartDef.StackGroups[group], parseList(series)...)
 			case "legendwidth":
 				if width, err := strconv.ParseFloat(value, 64); err == nil && width >= 0 && width <= 0.5 {
 					chartDef.LegendWidth = width
@@ -376,10 +504,128 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 				}
 			case "palette":
 				value = strings.ToLower(value)
-				if value == "auto" || value == "gradient" {
+				name := strings.TrimSpace(strings.TrimSuffix(value, "+patterns"))
+				if value == "patterns" {
+					// Patterns on their own keep the configured colors
+					chartDef.Patterns = true
+				} else if _, ok := gosvgchart.NamedPalette(name); ok || name == "auto" || name == "gradient" {
 					chartDef.Palette = value
 				} else {
-					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid palette value '%s' - must be 'auto' or 'gradient'", i+1, value))
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid palette value '%s' - must be 'auto', 'gradient' or one of %s, optionally followed by '+patterns'",
+						i+1, value, strings.Join(gosvgchart.PaletteNames(), ", ")))
+				}
+			case "theme":
+				if _, ok := gosvgchart.NamedTheme(value); ok {
+					chartDef.Theme = value
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: unknown theme '%s' - must be one of %s",
+						i+1, value, strings.Join(gosvgchart.ThemeNames(), ", ")))
+				}
+			case "font", "fontfamily":
+				chartDef.FontFamily = value
+			case "fontsizes", "fontsize":
+				var sizes []int
+				for _, part := range parseList(value) {
+					if size, err := strconv.Atoi(part); err == nil && size >= 0 {
+						sizes = append(sizes, size)
+					}
+				}
+				if len(sizes) == 0 || len(sizes) > 4 || len(sizes) != len(parseList(value)) {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid fontsizes value '%s' - must be up to four sizes in pixels for title, label, tick and legend", i+1, value))
+				} else {
+					chartDef.FontSizes = sizes
+				}
+			case "xlabels":
+				switch strings.ToLower(value) {
+				case gosvgchart.XLabelsAuto, gosvgchart.XLabelsHorizontal, gosvgchart.XLabelsWrap,
+					gosvgchart.XLabelsRotate45, gosvgchart.XLabelsRotate90, gosvgchart.XLabelsSkip:
+					chartDef.XLabels = strings.ToLower(value)
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid xlabels value '%s' - must be auto, horizontal, wrap, rotate45, rotate90 or skip", i+1, value))
+				}
+			case "xlabel", "xtitle":
+				chartDef.XAxisTitle = value
+			case "ylabel", "ytitle":
+				chartDef.YAxisTitle = value
+			case "xunit":
+				// Quotes keep leading spaces, as in yunit: " ms"
+				chartDef.XAxisUnit = strings.Trim(value, `"`)
+			case "yunit":
+				chartDef.YAxisUnit = strings.Trim(value, `"`)
+			case "annotate", "annotation":
+				if annotation, err := parseAnnotation(value); err == nil {
+					chartDef.Annotations = append(chartDef.Annotations, annotation)
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid annotate value '%s' - %v", i+1, value, err))
+				}
+			case "trendline", "trendlines", "movingaverage", "movingaverages":
+				if overlays, err := parseOverlays(value, strings.HasPrefix(key, "moving")); err == nil {
+					chartDef.Overlays = append(chartDef.Overlays, overlays...)
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid %s value '%s' - %v", i+1, key, value, err))
+				}
+			case "style":
+				if series, style, err := parseSeriesStyle(value); err == nil {
+					if chartDef.SeriesStyles == nil {
+						chartDef.SeriesStyles = make(map[string]gosvgchart.SeriesStyle)
+					}
+					chartDef.SeriesStyles[series] = style
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid style value '%s' - %v", i+1, value, err))
+				}
+			case "highlight":
+				if rule, err := parseHighlight(value); err == nil {
+					chartDef.Highlights = append(chartDef.Highlights, rule)
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid highlight value '%s' - %v", i+1, value, err))
+				}
+			case "url", "link":
+				if value == "" {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid %s value - expected '[series =] URL'", i+1, key))
+				} else if err := validateLinkValue(value); err != nil {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid %s value '%s' - %v", i+1, key, value, err))
+				} else {
+					chartDef.URLs = append(chartDef.URLs, value)
+				}
+			case "linktarget", "target":
+				chartDef.LinkTarget = value
+			case "dataattributes":
+				if b, ok := parseBool(value); ok {
+					chartDef.DataAttributes = b
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid dataattributes value '%s' - must be true/false, yes/no, or 1/0", i+1, value))
+				}
+			case "tooltip", "tooltips":
+				chartDef.Tooltip = value
+			case "hover":
+				if b, ok := parseBool(value); ok {
+					chartDef.Hover = b
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid hover value '%s' - must be true/false, yes/no, or 1/0", i+1, value))
+				}
+			case "interactive":
+				if b, ok := parseBool(value); ok {
+					chartDef.Interactive = b
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid interactive value '%s' - must be true/false, yes/no, or 1/0", i+1, value))
+				}
+			case "fitstats":
+				if b, ok := parseBool(value); ok {
+					chartDef.FitStats = b
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid fitstats value '%s' - must be true/false, yes/no, or 1/0", i+1, value))
+				}
+			case "xlabelstep":
+				if step, err := strconv.Atoi(value); err == nil && step > 0 {
+					chartDef.XLabelStep = step
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid xlabelstep value '%s' - must be a positive whole number", i+1, value))
+				}
+			case "patterns":
+				if b, ok := parseBool(value); ok {
+					chartDef.Patterns = b
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid patterns value '%s' - must be true/false, yes/no, or 1/0", i+1, value))
 				}
 			case "supportnegative":
 				if strings.ToLower(value) == "true" || strings.ToLower(value) == "yes" || value == "1" {
@@ -396,6 +642,170 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 				} else {
 					chartDef.NegativeColors = colorList
 				}
+			case "mode":
+				value = strings.ToLower(value)
+				if value == "line" || value == "bar" {
+					chartDef.SparkMode = value
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid mode value '%s' - must be 'line' or 'bar'", i+1, value))
+				}
+			case "showmin", "showmax", "showlast", "fill", "showvalues", "splityears":
+				b, ok := parseBool(value)
+				if !ok {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid %s value '%s' - must be true/false, yes/no, or 1/0", i+1, key, value))
+					continue
+				}
+				switch key {
+				case "showmin":
+					chartDef.ShowMin = b
+				case "showmax":
+					chartDef.ShowMax = b
+				case "showlast":
+					chartDef.ShowLast = b
+				case "fill":
+					chartDef.Fill = b
+				case "showvalues":
+					chartDef.ShowValues = b
+				case "splityears":
+					chartDef.SplitYears = b
+				}
+			case "weekstart":
+				switch strings.ToLower(value) {
+				case "sunday", "sun":
+					chartDef.WeekStart = time.Sunday
+				case "monday", "mon", "iso":
+					chartDef.WeekStart = time.Monday
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid weekstart value '%s' - must be 'sunday' or 'monday'", i+1, value))
+				}
+			case "aggregate", "aggregation":
+				value = strings.ToLower(value)
+				switch value {
+				case "sum", "mean", "max", "min", "last":
+					chartDef.Aggregation = value
+				case "avg", "average":
+					chartDef.Aggregation = "mean"
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid aggregate value '%s' - must be sum, mean, max, min, or last", i+1, value))
+				}
+			case "band":
+				bounds := parseList(value)
+				var band []float64
+				for _, bound := range bounds {
+					if v, err := strconv.ParseFloat(bound, 64); err == nil {
+						band = append(band, v)
+					}
+				}
+				if len(bounds) != 2 || len(band) != 2 {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid band value '%s' - must be two numbers such as '10, 20'", i+1, value))
+				} else {
+					chartDef.Band = band
+				}
+			case "colorscale":
+				stops := parseList(value)
+				if len(stops) >= 2 {
+					if err := gosvgchart.NewColorScale(stops...).Validate(); err != nil {
+						configErrors = append(configErrors, fmt.Sprintf("line %d: invalid colorscale value '%s' - %v", i+1, value, err))
+					} else {
+						chartDef.ColorScaleStops = stops
+					}
+				} else if _, ok := gosvgchart.NamedColorScale(value); ok {
+					chartDef.ColorScale = strings.ToLower(value)
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid colorscale value '%s' - must be one of %s, or a comma-separated list of colors",
+						i+1, value, strings.Join(gosvgchart.ColorScaleNames(), ", ")))
+				}
+			case "gaps":
+				value = strings.ToLower(value)
+				switch value {
+				case gosvgchart.GapBreak, gosvgchart.GapConnect, gosvgchart.GapInterpolate:
+					chartDef.Gaps = value
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid gaps value '%s' - must be break, connect, or interpolate", i+1, value))
+				}
+			case "downsample":
+				value = strings.ToLower(value)
+				switch value {
+				case gosvgchart.DownsampleLTTB, gosvgchart.DownsampleMinMax, gosvgchart.DownsampleAverage:
+					chartDef.Downsample = value
+				case "none", "false", "no":
+					chartDef.Downsample = gosvgchart.DownsampleNone
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid downsample value '%s' - must be lttb, minmax, average, or none", i+1, value))
+				}
+			case "interpolation", "curve":
+				value = strings.ToLower(value)
+				switch value {
+				case gosvgchart.InterpolateLinear, gosvgchart.InterpolateMonotone, gosvgchart.InterpolateCatmullRom,
+					gosvgchart.InterpolateStepBefore, gosvgchart.InterpolateStepAfter, gosvgchart.InterpolateStepMiddle:
+					chartDef.Interpolation = value
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid %s value '%s' - must be linear, monotone, catmull-rom, step-before, step-after, or step-middle", i+1, key, value))
+				}
+			case "tension":
+				if tension, err := strconv.ParseFloat(value, 64); err == nil && tension >= 0 && tension <= 1 {
+					chartDef.Tension = tension
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid tension value '%s' - must be a number from 0 to 1", i+1, value))
+				}
+			case "labels", "pielabels":
+				value = strings.ToLower(value)
+				switch value {
+				case gosvgchart.PieLabelsInside, gosvgchart.PieLabelsOutside:
+					chartDef.PieLabels = value
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid %s value '%s' - must be inside or outside", i+1, key, value))
+				}
+			case "other":
+				// Either a share of the total such as "5%" or the number of slices to keep such as "top 5"
+				if top, ok := strings.CutPrefix(strings.ToLower(value), "top"); ok {
+					if n, err := strconv.Atoi(strings.TrimSpace(top)); err == nil && n > 0 {
+						chartDef.OtherTopN = n
+						continue
+					}
+				} else if percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64); err == nil && percent > 0 && percent < 100 {
+					chartDef.OtherThreshold = percent
+					continue
+				}
+				configErrors = append(configErrors, fmt.Sprintf("line %d: invalid other value '%s' - must be a percentage such as 5%% or 'top N'", i+1, value))
+			case "otherlabel":
+				chartDef.OtherLabel = value
+			case "sort":
+				value = strings.ToLower(value)
+				switch value {
+				case gosvgchart.SortDescending, gosvgchart.SortAscending:
+					chartDef.SortSlices = value
+				case "none":
+					chartDef.SortSlices = gosvgchart.SortNone
+				default:
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid sort value '%s' - must be desc, asc, or none", i+1, value))
+				}
+			case "startangle":
+				if angle, err := strconv.ParseFloat(value, 64); err == nil {
+					chartDef.StartAngle = angle
+				} else {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid startangle value '%s' - must be a number of degrees", i+1, value))
+				}
+			case "explode":
+				chartDef.Explode = append(chartDef.Explode, parseList(value)...)
+			case "dateformat":
+				if value == "" {
+					configErrors = append(configErrors, fmt.Sprintf("line %d: invalid dateformat - must be a Go time layout such as 2006-01-02", i+1))
+				} else {
+					chartDef.DateFormat = value
+				}
+			case "today":
+				if b, ok := parseBool(value); ok {
+					chartDef.Today = ""
+					if b {
+						chartDef.Today = "now"
+					}
+				} else {
+					chartDef.Today = value
+					if value != "now" {
+						todayLine = i + 1
+					}
+				}
 			default:
 				//configErrors = append(configErrors, fmt.Sprintf("line %d: unknown configuration key '%s'", i+1, key))
 				continue //ignore made up parameters
@@ -403,6 +813,38 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 		}
 	}
 
+	// Sankey rows describe flows as "Source > Target | Value"
+	if chartDef.ChartType == "sankey" || chartDef.ChartType == "sankeychart" {
+		for _, label := range chartDef.Labels {
+			if _, _, ok := gosvgchart.ParseFlowLabel(label); !ok {
+				dataErrors = append(dataErrors, fmt.Sprintf("invalid flow '%s' - expected 'Source > Target | Value'", label))
+			}
+		}
+	}
+
+	// The today date is checked once the date format is known
+	if todayLine > 0 {
+		if _, err := time.Parse(chartDef.DateFormat, chartDef.Today); err != nil {
+			configErrors = append(configErrors, fmt.Sprintf("line %d: invalid today value '%s' - must be true/false, now, or a date in the format '%s'", todayLine, chartDef.Today, chartDef.DateFormat))
+		}
+	}
+
+	// Trendlines and moving averages must name one of the series
+	for _, overlay := range chartDef.Overlays {
+		if overlay.Series != "" && len(chartDef.Series) > 0 && !hasSeries(chartDef, overlay.Series) {
+			configErrors = append(configErrors, fmt.Sprintf("unknown series '%s' in %s overlay", overlay.Series, overlay.Kind))
+		}
+	}
+
+	// A series prefix must name one of the series, unless the whole value is a safe link
+	for _, link := range chartDef.URLs {
+		if name, _, ok := strings.Cut(link, "="); ok && !strings.Contains(name, "?") && !hasSeries(chartDef, strings.TrimSpace(name)) {
+			if gosvgchart.ValidateLinkURL(link) != nil {
+				configErrors = append(configErrors, fmt.Sprintf("unknown series '%s' in url", strings.TrimSpace(name)))
+			}
+		}
+	}
+
 	// Required validation
 	var errors []string
 	errors = append(errors, configErrors...)
@@ -413,7 +855,7 @@ func parseChartDefinition(markdown string, chartIndex int) (ChartDefinition, err
 	}
 
 	// Check if we have data in either the legacy format or series format
-	hasData := len(chartDef.Data) > 0
+	hasData := len(chartDef.Data) > 0 || len(chartDef.Tasks) > 0
 	for _, series := range chartDef.Series {
 		if len(series.Data) > 0 {
 			hasData = true
@@ -446,17 +888,49 @@ func renderChartFromDefinition(chartDef ChartDefinition) (string, error) {
 		if len(chartDef.Series) > 0 {
 			lineChart.ShowLegend = true
 		}
+		lineChart.SetFillArea(chartDef.Fill)
+		lineChart.SetGapMode(chartDef.Gaps).SetDownsampling(chartDef.Downsample)
+		lineChart.SetInterpolation(chartDef.Interpolation).SetTension(chartDef.Tension)
+		lineChart.SetXLabelStrategy(chartDef.XLabels).SetXLabelStep(chartDef.XLabelStep)
+		lineChart.SetXAxisTitle(chartDef.XAxisTitle).SetYAxisTitle(chartDef.YAxisTitle)
+		lineChart.SetXAxisUnit(chartDef.XAxisUnit).SetYAxisUnit(chartDef.YAxisUnit)
+		for _, annotation := range chartDef.Annotations {
+			lineChart.AddAnnotation(annotation)
+		}
+		for _, overlay := range seriesOverlays(chartDef) {
+			lineChart.AddOverlay(overlay)
+		}
+		lineChart.SetShowFitStats(chartDef.FitStats)
 	case "bar", "barchart":
 		barChart := gosvgchart.NewBarChart()
 		chart = barChart
 		// Set stacked property if specified
 		barChart.Stacked = chartDef.Stacked
+		barChart.SetStackMode(chartDef.StackMode)
+		if len(chartDef.StackGroups) > 0 && chartDef.StackMode == "" {
+			// Stack groups imply stacks side by side
+			barChart.SetStackMode(gosvgchart.StackGroupedStacked)
+		}
+		barChart.SetXLabelStrategy(chartDef.XLabels).SetXLabelStep(chartDef.XLabelStep)
+		barChart.SetXAxisTitle(chartDef.XAxisTitle).SetYAxisTitle(chartDef.YAxisTitle)
+		barChart.SetXAxisUnit(chartDef.XAxisUnit).SetYAxisUnit(chartDef.YAxisUnit)
+		for _, annotation := range chartDef.Annotations {
+			barChart.AddAnnotation(annotation)
+		}
+		for _, overlay := range seriesOverlays(chartDef) {
+			barChart.AddOverlay(overlay)
+		}
+		barChart.SetShowFitStats(chartDef.FitStats)
 		// Enable legend for multiple series
 		if len(chartDef.Series) > 0 {
 			barChart.ShowLegend = true
 		}
 	case "pie", "piechart":
-		chart = gosvgchart.NewPieChart()
+		pieChart := gosvgchart.NewPieChart()
+		chart = pieChart
+		pieChart.SetLabelMode(chartDef.PieLabels).SetSortSlices(chartDef.SortSlices).SetStartAngle(chartDef.StartAngle)
+		pieChart.SetOtherThreshold(chartDef.OtherThreshold).SetOtherTopN(chartDef.OtherTopN).SetOtherLabel(chartDef.OtherLabel)
+		pieChart.ExplodeSlices(chartDef.Explode...)
 	case "heatmap", "heatmapchart":
 		heatmapChart := gosvgchart.NewHeatmapChart()
 		chart = heatmapChart
@@ -469,6 +943,87 @@ func renderChartFromDefinition(chartDef ChartDefinition) (string, error) {
 		if len(chartDef.NegativeColors) > 0 {
 			heatmapChart.SetNegativeColors(chartDef.NegativeColors)
 		}
+
+		heatmapChart.SetDateFormat(chartDef.DateFormat)
+		heatmapChart.SetWeekStart(chartDef.WeekStart)
+		heatmapChart.SetSplitYears(chartDef.SplitYears)
+		if chartDef.Aggregation != "" {
+			heatmapChart.SetAggregation(chartDef.Aggregation)
+		}
+
+		// Tabular series data becomes a matrix with one column per series
+		if len(chartDef.Series) > 0 {
+			columns := make([]string, len(chartDef.Series))
+			for j, series := range chartDef.Series {
+				columns[j] = series.Name
+			}
+			values := make([][]float64, len(chartDef.Labels))
+			for i := range chartDef.Labels {
+				values[i] = make([]float64, len(chartDef.Series))
+				for j, series := range chartDef.Series {
+					if i < len(series.Data) {
+						values[i][j] = series.Data[i]
+					}
+				}
+			}
+			heatmapChart.SetMatrix(chartDef.Labels, columns, values)
+		}
+		heatmapChart.SetShowValues(chartDef.ShowValues)
+
+		if chartDef.ColorScale != "" {
+			heatmapChart.SetColorScale(chartDef.ColorScale)
+		} else if len(chartDef.ColorScaleStops) > 0 {
+			heatmapChart.SetCustomColorScale(chartDef.ColorScaleStops)
+		}
+	case "sankey", "sankeychart":
+		chart = gosvgchart.NewSankeyChart()
+	case "sparkline", "sparklinechart":
+		sparkline := gosvgchart.NewSparklineChart()
+		chart = sparkline
+
+		if chartDef.SparkMode != "" {
+			sparkline.SetMode(chartDef.SparkMode)
+		}
+		sparkline.SetHighlights(chartDef.ShowMin, chartDef.ShowMax, chartDef.ShowLast)
+		sparkline.SetFill(chartDef.Fill)
+		if len(chartDef.Band) == 2 {
+			sparkline.SetReferenceBand(chartDef.Band[0], chartDef.Band[1])
+		}
+	case "gantt", "ganttchart":
+		ganttChart := gosvgchart.NewGanttChart()
+		chart = ganttChart
+
+		ganttChart.SetDateFormat(chartDef.DateFormat)
+		if chartDef.Today == "now" {
+			ganttChart.SetShowToday(true)
+		} else if chartDef.Today != "" {
+			ganttChart.SetToday(chartDef.Today)
+		}
+		for _, task := range chartDef.Tasks {
+			if task.Milestone {
+				ganttChart.AddMilestone(task.Name, task.Start)
+			} else {
+				ganttChart.AddTask(task.Name, task.Start, task.End, task.Progress)
+			}
+		}
+	}
+
+	// Apply the theme first so explicit settings below take precedence
+	if theme, ok := gosvgchart.NamedTheme(chartDef.Theme); ok {
+		gosvgchart.ApplyTheme(chart, theme)
+	}
+
+	if typed, ok := chart.(interface {
+		SetFontFamily(string) *gosvgchart.BaseChart
+		SetFontSizes(title, label, tick, legend int) *gosvgchart.BaseChart
+	}); ok {
+		if chartDef.FontFamily != "" {
+			typed.SetFontFamily(chartDef.FontFamily)
+		}
+		if len(chartDef.FontSizes) > 0 {
+			sizes := append(chartDef.FontSizes, 0, 0, 0)
+			typed.SetFontSizes(sizes[0], sizes[1], sizes[2], sizes[3])
+		}
 	}
 
 	// Set basic properties
@@ -496,6 +1051,9 @@ func renderChartFromDefinition(chartDef ChartDefinition) (string, error) {
 	if chartDef.Palette != "" {
 		chart.SetPalette(chartDef.Palette)
 	}
+	if chartDef.Patterns {
+		chart.SetPalette("patterns")
+	}
 
 	// Check if we have multiple series
 	if len(chartDef.Series) > 0 {
@@ -509,6 +1067,83 @@ func renderChartFromDefinition(chartDef ChartDefinition) (string, error) {
 		chart.SetData(chartDef.Data)
 	}
 
+	// Error bounds from "value ± error" and "value [lower, upper]" cells
+	if typed, ok := chart.(interface {
+		SetErrorBounds(series string, lower, upper []float64) *gosvgchart.BaseChart
+	}); ok {
+		for _, series := range chartDef.Series {
+			if hasBounds(series.Data, series.Lower, series.Upper) {
+				typed.SetErrorBounds(series.Name, series.Lower, series.Upper)
+			}
+		}
+		if hasBounds(chartDef.Data, chartDef.DataLower, chartDef.DataUpper) {
+			typed.SetErrorBounds("", chartDef.DataLower, chartDef.DataUpper)
+		}
+	}
+
+	// Bar stack groups from "stackgroup: Group = Series, Series" lines
+	if typed, ok := chart.(interface {
+		SetStackGroup(group string, series ...string) *gosvgchart.BarChart
+	}); ok {
+		for group, series := range chartDef.StackGroups {
+			typed.SetStackGroup(group, series...)
+		}
+	}
+
+	// Line styles from "style: Series = dash 6 4, marker square" lines
+	if typed, ok := chart.(interface {
+		SetSeriesStyle(series string, style gosvgchart.SeriesStyle) *gosvgchart.BaseChart
+	}); ok {
+		for series, style := range chartDef.SeriesStyles {
+			typed.SetSeriesStyle(series, style)
+		}
+	}
+
+	// Conditional coloring from "highlight: above 100, color red" lines
+	if typed, ok := chart.(interface {
+		AddHighlight(rule gosvgchart.HighlightRule) *gosvgchart.BaseChart
+	}); ok {
+		for _, rule := range chartDef.Highlights {
+			typed.AddHighlight(rule)
+		}
+	}
+
+	// Links from "url: [Series =] /path?label={label}" lines, a URL's query string may
+	// contain "=" too, so the prefix only names a series when one has that name
+	if typed, ok := chart.(interface {
+		SetSeriesURL(series, url string) *gosvgchart.BaseChart
+		SetLinkTarget(target string) *gosvgchart.BaseChart
+		SetDataAttributes(enabled bool) *gosvgchart.BaseChart
+	}); ok {
+		for _, link := range chartDef.URLs {
+			if name, url, ok := strings.Cut(link, "="); ok && hasSeries(chartDef, strings.TrimSpace(name)) {
+				typed.SetSeriesURL(strings.TrimSpace(name), strings.TrimSpace(url))
+			} else if len(chartDef.Series) > 0 {
+				for _, series := range chartDef.Series {
+					typed.SetSeriesURL(series.Name, link)
+				}
+			} else {
+				typed.SetSeriesURL("", link)
+			}
+		}
+		typed.SetLinkTarget(chartDef.LinkTarget).SetDataAttributes(chartDef.DataAttributes)
+	}
+
+	// Tooltips from "tooltip: {series}: {value:si} on {label}" and CSS hover highlighting
+	if typed, ok := chart.(interface {
+		SetTooltip(template string) *gosvgchart.BaseChart
+		SetHoverHighlight(enabled bool) *gosvgchart.BaseChart
+	}); ok {
+		typed.SetTooltip(chartDef.Tooltip).SetHoverHighlight(chartDef.Hover)
+	}
+
+	// Crosshair, legend toggle and zoom from "interactive: true", script-free otherwise
+	if typed, ok := chart.(interface {
+		SetInteractive(enabled bool) *gosvgchart.BaseChart
+	}); ok {
+		typed.SetInteractive(chartDef.Interactive)
+	}
+
 	// Set labels
 	if len(chartDef.Labels) > 0 {
 		chart.SetLabels(chartDef.Labels)
@@ -523,6 +1158,386 @@ func renderChartFromDefinition(chartDef ChartDefinition) (string, error) {
 	return chart.Render(), nil
 }
 
+// parseTaskRow parses a Gantt row of the form "Task | Start | End | Progress"
+// A row with a single date is a milestone
+func parseTaskRow(line, dateFormat string) (TaskDefinition, error) {
+	parts := strings.Split(line, "|")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	task := TaskDefinition{Name: parts[0], Progress: -1}
+	if task.Name == "" {
+		return task, fmt.Errorf("missing task name before '|'")
+	}
+	if len(parts) < 2 || len(parts) > 4 {
+		return task, fmt.Errorf("invalid task format, expected 'Task | Start | End | Progress'")
+	}
+
+	for _, date := range parts[1:min(len(parts), 3)] {
+		if _, err := time.Parse(dateFormat, date); err != nil {
+			return task, fmt.Errorf("'%s' is not a valid date for format '%s'", date, dateFormat)
+		}
+	}
+
+	task.Start = parts[1]
+	if len(parts) == 2 {
+		task.Milestone = true
+		task.End = parts[1]
+		return task, nil
+	}
+	task.End = parts[2]
+
+	if len(parts) == 4 && parts[3] != "" {
+		// Progress is a percentage with a percent sign, such as 40%, or a fraction from 0 to 1
+		percent := strings.HasSuffix(parts[3], "%")
+		progress, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(parts[3], "%")), 64)
+		if percent {
+			progress /= 100
+		}
+		if err != nil || progress < 0 || progress > 1 {
+			return task, fmt.Errorf("'%s' is not a valid progress - must be a percentage such as 40%% or a fraction between 0 and 1", parts[3])
+		}
+		task.Progress = progress
+	}
+
+	return task, nil
+}
+
+// parseAnnotation parses an annotation such as `hline 99.9 "SLO"`, `vband Feb Mar "Freeze"`
+// or `callout Mar Sales "deploy v2.3"`
+// Labels with spaces are quoted, the final quoted text is optional except for callouts
+func parseAnnotation(value string) (gosvgchart.Annotation, error) {
+	// The quoted part at the end is the text, other quotes group words with spaces
+	var fields []string
+	var text string
+	parts := strings.Split(value, `"`)
+	for i, part := range parts {
+		switch {
+		case i%2 == 0:
+			fields = append(fields, strings.Fields(part)...)
+		case i == len(parts)-2 && strings.TrimSpace(parts[i+1]) == "":
+			text = part
+		default:
+			fields = append(fields, part)
+		}
+	}
+	if len(fields) == 0 {
+		return gosvgchart.Annotation{}, fmt.Errorf("must start with hline, vline, hband, vband or callout")
+	}
+
+	annotation := gosvgchart.Annotation{Kind: strings.ToLower(fields[0]), Text: text}
+	args := fields[1:]
+	parseValue := func(s string) (float64, error) {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("'%s' is not a valid number", s)
+		}
+		return v, nil
+	}
+
+	var err error
+	switch annotation.Kind {
+	case gosvgchart.AnnotationHLine:
+		if len(args) != 1 {
+			return annotation, fmt.Errorf(`expected 'hline <value> "text"'`)
+		}
+		annotation.From, err = parseValue(args[0])
+	case gosvgchart.AnnotationHBand:
+		if len(args) != 2 {
+			return annotation, fmt.Errorf(`expected 'hband <from> <to> "text"'`)
+		}
+		if annotation.From, err = parseValue(args[0]); err == nil {
+			annotation.To, err = parseValue(args[1])
+		}
+		annotation.From, annotation.To = min(annotation.From, annotation.To), max(annotation.From, annotation.To)
+	case gosvgchart.AnnotationVLine:
+		if len(args) != 1 {
+			return annotation, fmt.Errorf(`expected 'vline <label> "text"'`)
+		}
+		annotation.At = args[0]
+	case gosvgchart.AnnotationVBand:
+		if len(args) != 2 {
+			return annotation, fmt.Errorf(`expected 'vband <from label> <to label> "text"'`)
+		}
+		annotation.At, annotation.Until = args[0], args[1]
+	case gosvgchart.AnnotationCallout:
+		if len(args) < 1 || len(args) > 2 || text == "" {
+			return annotation, fmt.Errorf(`expected 'callout <label> [series] "text"'`)
+		}
+		annotation.At = args[0]
+		if len(args) == 2 {
+			annotation.Series = args[1]
+		}
+	default:
+		return annotation, fmt.Errorf("must start with hline, vline, hband, vband or callout")
+	}
+	return annotation, err
+}
+
+// parseOverlays parses a comma-separated list of trendlines or moving averages such as
+// "linear", "Sales=polynomial 3" or "Sales=sma 7, Costs=ema 5"
+// Entries without a series name apply to every series
+func parseOverlays(value string, movingAverage bool) ([]gosvgchart.Overlay, error) {
+	var overlays []gosvgchart.Overlay
+	for _, entry := range parseList(value) {
+		var overlay gosvgchart.Overlay
+		if name, spec, ok := strings.Cut(entry, "="); ok {
+			overlay.Series = strings.TrimSpace(name)
+			entry = spec
+		}
+		fields := strings.Fields(strings.ToLower(entry))
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("expected '[series=]kind [n]'")
+		}
+		overlay.Kind = fields[0]
+		n := 0
+		if len(fields) == 2 {
+			var err error
+			if n, err = strconv.Atoi(fields[1]); err != nil || n < 1 {
+				return nil, fmt.Errorf("'%s' is not a positive whole number", fields[1])
+			}
+		}
+		switch {
+		case movingAverage && (overlay.Kind == gosvgchart.MovingAverageSMA || overlay.Kind == gosvgchart.MovingAverageEMA):
+			overlay.Window = n
+		case !movingAverage && overlay.Kind == gosvgchart.TrendPolynomial:
+			overlay.Degree = n
+		case !movingAverage && (overlay.Kind == gosvgchart.TrendLinear || overlay.Kind == gosvgchart.TrendExponential):
+			if n > 0 {
+				return nil, fmt.Errorf("%s trendlines take no number, only polynomial takes a degree", overlay.Kind)
+			}
+		case movingAverage:
+			return nil, fmt.Errorf("kind must be sma or ema")
+		default:
+			return nil, fmt.Errorf("kind must be linear, polynomial or exponential")
+		}
+		overlays = append(overlays, overlay)
+	}
+	if len(overlays) == 0 {
+		return nil, fmt.Errorf("expected '[series=]kind [n]'")
+	}
+	return overlays, nil
+}
+
+// seriesOverlays returns the chart's overlays with those for every series repeated per series
+func seriesOverlays(chartDef ChartDefinition) []gosvgchart.Overlay {
+	var overlays []gosvgchart.Overlay
+	for _, overlay := range chartDef.Overlays {
+		if overlay.Series != "" || len(chartDef.Series) == 0 {
+			overlays = append(overlays, overlay)
+			continue
+		}
+		for _, series := range chartDef.Series {
+			overlay.Series = series.Name
+			overlays = append(overlays, overlay)
+		}
+	}
+	return overlays
+}
+
+// parseSeriesStyle parses "[series =] property value, ..." with the properties dash
+// (solid, dashed, dotted, dashdot or a list of lengths), width, opacity, marker and size
+func parseSeriesStyle(value string) (string, gosvgchart.SeriesStyle, error) {
+	var series string
+	var style gosvgchart.SeriesStyle
+	if name, spec, ok := strings.Cut(value, "="); ok {
+		series = strings.TrimSpace(name)
+		value = spec
+	}
+	properties := parseList(value)
+	if len(properties) == 0 {
+		return "", style, fmt.Errorf("expected '[series =] property value, ...'")
+	}
+	for _, property := range properties {
+		fields := strings.Fields(strings.ToLower(property))
+		if len(fields) < 2 {
+			return "", style, fmt.Errorf("'%s' needs a value", property)
+		}
+		name, args := fields[0], fields[1:]
+		switch name {
+		case "dash":
+			dashes := map[string]string{"solid": gosvgchart.DashSolid, "dashed": gosvgchart.DashDashed,
+				"dotted": gosvgchart.DashDotted, "dashdot": gosvgchart.DashDashDot}
+			if dash, ok := dashes[args[0]]; ok && len(args) == 1 {
+				style.Dash = dash
+				continue
+			}
+			for _, arg := range args {
+				if n, err := strconv.ParseFloat(arg, 64); err != nil || n < 0 {
+					return "", style, fmt.Errorf("dash must be solid, dashed, dotted, dashdot or lengths such as '6 4'")
+				}
+			}
+			style.Dash = strings.Join(args, ",")
+		case "marker":
+			switch args[0] {
+			case gosvgchart.MarkerCircle, gosvgchart.MarkerSquare, gosvgchart.MarkerTriangle,
+				gosvgchart.MarkerDiamond, gosvgchart.MarkerCross, gosvgchart.MarkerNone:
+				style.Marker = args[0]
+			default:
+				return "", style, fmt.Errorf("marker must be circle, square, triangle, diamond, cross or none")
+			}
+		case "width", "size", "opacity":
+			n, err := strconv.ParseFloat(args[0], 64)
+			if err != nil || n <= 0 || (name == "opacity" && n > 1) || len(args) > 1 {
+				return "", style, fmt.Errorf("%s must be a positive number", name)
+			}
+			switch name {
+			case "width":
+				style.Width = n
+			case "size":
+				style.MarkerSize = n
+			case "opacity":
+				style.Opacity = n
+			}
+		default:
+			return "", style, fmt.Errorf("unknown property '%s' - must be dash, width, opacity, marker or size", name)
+		}
+	}
+	return series, style, nil
+}
+
+// parseHighlight parses a conditional coloring rule such as "Series = above 100, color red, opacity 0.8, emphasis"
+// The condition comes first: "above N", "below N" or "label Name"; a rule without a color emphasizes the points
+func parseHighlight(value string) (gosvgchart.HighlightRule, error) {
+	var rule gosvgchart.HighlightRule
+	if name, spec, ok := strings.Cut(value, "="); ok {
+		rule.Series = strings.TrimSpace(name)
+		value = spec
+	}
+	properties := parseList(value)
+	if len(properties) == 0 {
+		return rule, fmt.Errorf("expected '[series =] above N | below N | label Name, color C, ...'")
+	}
+
+	kind, arg, _ := strings.Cut(properties[0], " ")
+	arg = strings.TrimSpace(arg)
+	switch rule.Kind = strings.ToLower(kind); rule.Kind {
+	case gosvgchart.HighlightAbove, gosvgchart.HighlightBelow:
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return rule, fmt.Errorf("%s needs a number", rule.Kind)
+		}
+		rule.Value = n
+	case gosvgchart.HighlightLabel:
+		if arg == "" {
+			return rule, fmt.Errorf("label needs a category name")
+		}
+		rule.Label = arg
+	default:
+		return rule, fmt.Errorf("condition must be 'above N', 'below N' or 'label Name'")
+	}
+
+	for _, property := range properties[1:] {
+		fields := strings.Fields(property)
+		switch name := strings.ToLower(fields[0]); {
+		case name == "emphasis" && len(fields) == 1:
+			rule.Style.Emphasis = true
+		case name == "color" && len(fields) == 2:
+			rule.Style.Color = fields[1]
+		case name == "opacity" && len(fields) == 2:
+			n, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil || n <= 0 || n > 1 {
+				return rule, fmt.Errorf("opacity must be a number from 0 to 1")
+			}
+			rule.Style.Opacity = n
+		default:
+			return rule, fmt.Errorf("unknown property '%s' - must be color, opacity or emphasis", property)
+		}
+	}
+	if rule.Style.Color == "" {
+		rule.Style.Emphasis = true
+	}
+	return rule, nil
+}
+
+// parseDataCell parses a data value, optionally with error bounds as "value ± error",
+// "value +/- error" or "value [lower, upper]"
+// Values without bounds have lower and upper bounds equal to the value
+// Empty cells and "-" are missing values
+func parseDataCell(cell string) (value, lower, upper float64, err error) {
+	if cell == "" || cell == "-" {
+		missing := gosvgchart.Missing()
+		return missing, missing, missing, nil
+	}
+	number := func(s string) (float64, error) {
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	}
+
+	if v, bounds, ok := strings.Cut(cell, "["); ok && strings.HasSuffix(cell, "]") {
+		lo, hi, ok := strings.Cut(strings.TrimSuffix(bounds, "]"), ",")
+		if value, err = number(v); err == nil && ok {
+			if lower, err = number(lo); err == nil {
+				upper, err = number(hi)
+			}
+		}
+		if err != nil || !ok {
+			return 0, 0, 0, fmt.Errorf("invalid bounds")
+		}
+		return value, math.Min(lower, upper), math.Max(lower, upper), nil
+	}
+
+	for _, separator := range []string{"±", "+/-"} {
+		if v, e, ok := strings.Cut(cell, separator); ok {
+			var spread float64
+			if value, err = number(v); err == nil {
+				spread, err = number(e)
+			}
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			return value, value - math.Abs(spread), value + math.Abs(spread), nil
+		}
+	}
+
+	value, err = number(cell)
+	return value, value, value, err
+}
+
+// hasBounds reports whether any value has error bounds different from the value itself
+func hasBounds(data, lower, upper []float64) bool {
+	for i := range data {
+		if gosvgchart.IsMissing(data[i]) {
+			continue
+		}
+		if i < len(lower) && i < len(upper) && (lower[i] != data[i] || upper[i] != data[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateLinkValue checks that the URL of a "[series =] URL" value is relative or uses a safe
+// scheme, since the SVG is inlined into pages; an "=" after a "?" belongs to the query string,
+// any other "=" ends a series name
+func validateLinkValue(value string) error {
+	if name, url, ok := strings.Cut(value, "="); ok && !strings.Contains(name, "?") {
+		return gosvgchart.ValidateLinkURL(strings.TrimSpace(url))
+	}
+	return gosvgchart.ValidateLinkURL(value)
+}
+
+// hasSeries reports whether the chart has a series with this name
+func hasSeries(chartDef ChartDefinition, name string) bool {
+	for _, series := range chartDef.Series {
+		if series.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBool parses true/false, yes/no and 1/0 values
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "1":
+		return true, true
+	case "false", "no", "0":
+		return false, true
+	}
+	return false, false
+}
+
 // parseList splits a comma-separated list and trims each element
 func parseList(input string) []string {
 	parts := strings.Split(input, ",")
@@ -217,8 +217,8 @@ data:`
 		t.Errorf("Expected error about no data points, got: %v", err)
 	}
 	
-	// Test mismatched labels and data
-	mismatchedLabelsMD := `linechart
+	// Test a label without a value, which is a missing value
+	missingValueMD := `linechart
 title: Test Chart
 width: 600
 height: 400
@@ -229,12 +229,9 @@ B | 20
 C |
 D | 40`
 
-	_, err = ParseMarkdownChart(mismatchedLabelsMD)
-	if err == nil {
-		t.Error("Expected error for mismatched labels and data, but got none")
-	} else if !strings.Contains(err.Error(), "mismatched labels and data points") || 
-		!strings.Contains(err.Error(), "not a valid number") {
-		t.Errorf("Expected error about mismatched data points, got: %v", err)
+	_, err = ParseMarkdownChart(missingValueMD)
+	if err != nil {
+		t.Errorf("Expected a label without a value to parse as a missing value, got: %v", err)
 	}
 	
 	// Test multiple errors
@@ -309,4 +306,1529 @@ Y | 40`
 	if svgCount != 2 {
 		t.Errorf("Expected 2 SVG elements, got %d", svgCount)
 	}
-}
\ No newline at end of file
+}
+
+func TestParseSankeyChart(t *testing.T) {
+	sankeyMD := `sankeychart
+title: Traffic Flow
+width: 800
+height: 400
+
+data:
+Search > Home | 120
+Social > Home | 60
+Search > Pricing | 40
+Home > Pricing | 70
+Home > Signup | 50
+Pricing > Signup | 45`
+
+	svg, err := ParseMarkdownChart(sankeyMD)
+	if err != nil {
+		t.Fatalf("Error parsing sankey chart: %v", err)
+	}
+
+	if !strings.Contains(svg, "Traffic Flow") {
+		t.Error("Sankey chart doesn't contain title")
+	}
+
+	for _, node := range []string{"Search", "Social", "Home", "Pricing", "Signup"} {
+		if !strings.Contains(svg, ">"+node+"</text>") {
+			t.Errorf("Sankey chart doesn't contain node label %q", node)
+		}
+	}
+
+	if !strings.Contains(svg, "<title>Home → Signup: 50</title>") {
+		t.Error("Sankey chart doesn't contain flow tooltip")
+	}
+
+	// Rows without a target are rejected
+	invalidMD := `sankeychart
+data:
+Search | 120`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid flow, but got none")
+	} else if !strings.Contains(err.Error(), "invalid flow") {
+		t.Errorf("Expected error about invalid flow, got: %v", err)
+	}
+}
+
+func TestParseGanttChart(t *testing.T) {
+	ganttMD := `ganttchart
+title: Project Plan
+width: 800
+height: 300
+
+data:
+Design | 2025-01-03 | 2025-02-10 | 40%
+Build | 2025-02-01 | 2025-03-15 | 0.1
+Launch | 2025-03-20`
+
+	svg, err := ParseMarkdownChart(ganttMD)
+	if err != nil {
+		t.Fatalf("Error parsing gantt chart: %v", err)
+	}
+
+	if !strings.Contains(svg, "Project Plan") {
+		t.Error("Gantt chart doesn't contain title")
+	}
+
+	if !strings.Contains(svg, "<title>Design: 2025-01-03 – 2025-02-10 (40%)</title>") {
+		t.Error("Gantt chart doesn't contain task tooltip with progress")
+	}
+
+	if !strings.Contains(svg, "<polygon") || !strings.Contains(svg, "<title>Launch: 2025-03-20</title>") {
+		t.Error("Gantt chart doesn't render the milestone as a diamond")
+	}
+
+	// The today marker is opt-in, so the output doesn't depend on the render date
+	if strings.Contains(svg, ">Today</text>") {
+		t.Error("Gantt chart shouldn't draw a today marker by default")
+	}
+	svg, err = ParseMarkdownChart(strings.Replace(ganttMD, "height: 300", "height: 300\ntoday: 2025-02-20", 1))
+	if err != nil {
+		t.Fatalf("Error parsing gantt chart with today: %v", err)
+	}
+	if !strings.Contains(svg, ">Today</text>") {
+		t.Error("Gantt chart doesn't draw the today marker at a fixed date")
+	}
+
+	// Bare progress numbers are fractions, percentages need a percent sign
+	_, err = ParseMarkdownChart(strings.Replace(ganttMD, "| 0.1", "| 2", 1))
+	if err == nil || !strings.Contains(err.Error(), "not a valid progress") {
+		t.Errorf("Expected error about progress above 1 without a percent sign, got: %v", err)
+	}
+
+	// Invalid dates are reported with the line number
+	invalidMD := `ganttchart
+data:
+Design | 2025-13-03 | 2025-02-10`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid date, but got none")
+	} else if !strings.Contains(err.Error(), "not a valid date") {
+		t.Errorf("Expected error about invalid date, got: %v", err)
+	}
+}
+
+func TestParseSparkline(t *testing.T) {
+	sparklineMD := `sparkline
+showmin: true
+showmax: true
+showlast: true
+band: 10, 20
+
+data:
+Mon | 12
+Tue | 8
+Wed | 15
+Thu | 22
+Fri | 18`
+
+	svg, err := ParseMarkdownChart(sparklineMD)
+	if err != nil {
+		t.Fatalf("Error parsing sparkline: %v", err)
+	}
+
+	// Sparklines are sized for inline text flow
+	if !strings.Contains(svg, `<svg width="100" height="24"`) {
+		t.Errorf("Sparkline should default to a fixed inline size, got: %s", svg[:80])
+	}
+
+	if strings.Contains(svg, "<text") {
+		t.Error("Sparkline shouldn't contain axis labels or a legend")
+	}
+
+	if strings.Count(svg, "<circle") != 3 {
+		t.Errorf("Expected 3 highlighted points, got %d", strings.Count(svg, "<circle"))
+	}
+
+	barMD := `sparkline
+mode: bar
+width: 80
+height: 20
+
+data:
+1 | 3
+2 | -2
+3 | 5`
+
+	svg, err = ParseMarkdownChart(barMD)
+	if err != nil {
+		t.Fatalf("Error parsing bar sparkline: %v", err)
+	}
+
+	if strings.Count(svg, "<rect") != 3 {
+		t.Errorf("Expected 3 bars, got %d", strings.Count(svg, "<rect"))
+	}
+
+	// Bars link, carry data attributes and use the tooltip template like the marks of other charts
+	svg, err = ParseMarkdownChart(strings.Replace(barMD, "height: 20", "height: 20\nurl: /days/{label}\ndataattributes: true\ntooltip: Day {label}: {value}\nhover: true", 1))
+	if err != nil {
+		t.Fatalf("Error parsing linked bar sparkline: %v", err)
+	}
+	if !strings.Contains(svg, `<a href="/days/2" class="chart-mark"><title>Day 2: -2</title><rect`) {
+		t.Error("Expected a link with the templated tooltip around the second bar")
+	}
+	if !strings.Contains(svg, `data-label="3" data-value="5"`) {
+		t.Error("Expected data attributes on the bars")
+	}
+	if !strings.Contains(svg, ":has(.chart-mark:hover)") {
+		t.Error("Expected the hover highlighting style")
+	}
+}
+
+func TestParseMatrixHeatmap(t *testing.T) {
+	matrixMD := `heatmapchart
+title: Errors by Service and Region
+width: 600
+height: 300
+showvalues: true
+
+series:
+Service | eu-west | us-east | ap-south
+api | 12 | 30 | 4
+auth | 0 | 7 | 2
+billing | 3 | 1 | 15`
+
+	svg, err := ParseMarkdownChart(matrixMD)
+	if err != nil {
+		t.Fatalf("Error parsing matrix heatmap: %v", err)
+	}
+
+	for _, label := range []string{"eu-west", "us-east", "ap-south", "api", "auth", "billing"} {
+		if !strings.Contains(svg, ">"+label+"</text>") {
+			t.Errorf("Matrix heatmap doesn't contain label %q", label)
+		}
+	}
+
+	if !strings.Contains(svg, "<title>billing, ap-south: 15</title>") {
+		t.Error("Matrix heatmap doesn't contain cell tooltip")
+	}
+
+	// The darkest cell gets white text for contrast
+	if !strings.Contains(svg, `fill="#ffffff">30</text>`) {
+		t.Error("Matrix heatmap doesn't use contrasting text on dark cells")
+	}
+}
+
+func TestParseColorScale(t *testing.T) {
+	scaleMD := `heatmapchart
+title: Load
+colorscale: viridis
+
+series:
+Hour | Mon | Tue
+00 | 1 | 5
+12 | 9 | 3`
+
+	svg, err := ParseMarkdownChart(scaleMD)
+	if err != nil {
+		t.Fatalf("Error parsing heatmap with color scale: %v", err)
+	}
+
+	if !strings.Contains(svg, "<linearGradient") {
+		t.Error("Heatmap with color scale should have a gradient legend")
+	}
+
+	// The maximum value maps to the top of the viridis scale
+	if !strings.Contains(svg, `fill="#fde725"><title>12, Mon: 9</title>`) {
+		t.Error("Heatmap cell colors should be interpolated from the color scale")
+	}
+
+	customMD := `heatmapchart
+colorscale: #ffffff, #000000
+
+data:
+2025-01-01 | 1
+2025-01-02 | 2`
+
+	if _, err := ParseMarkdownChart(customMD); err != nil {
+		t.Errorf("Error parsing heatmap with custom color scale: %v", err)
+	}
+
+	// Stops that aren't hex colors can't be interpolated
+	_, err = ParseMarkdownChart(strings.Replace(customMD, "#ffffff, #000000", "#ffffff, red", 1))
+	if err == nil || !strings.Contains(err.Error(), "stop 'red' must be a #rrggbb color") {
+		t.Errorf("Expected error about a non-hex color stop, got: %v", err)
+	}
+
+	// Sequential scales span the data range, so the smallest value maps to the first stop
+	svg, err = ParseMarkdownChart(strings.Replace(customMD, "| 1\n", "| 101\n", 1))
+	if err != nil {
+		t.Fatalf("Error parsing heatmap with custom color scale: %v", err)
+	}
+	if !strings.Contains(svg, `fill="#ffffff"`) {
+		t.Error("The smallest value should map to the first color stop")
+	}
+
+	// Days without data keep the empty color instead of the color of the smallest value
+	svg, err = ParseMarkdownChart(strings.Replace(customMD, "2025-01-02 | 2", "2025-01-05 | 2", 1))
+	if err != nil {
+		t.Fatalf("Error parsing heatmap with custom color scale: %v", err)
+	}
+	if !strings.Contains(svg, `fill="#ebedf0"><title>Thu, Jan 2, 2025: no data</title>`) {
+		t.Error("Days without data should be drawn in the empty color")
+	}
+	if !strings.Contains(svg, `fill="#ffffff"><title>Wed, Jan 1, 2025: 1</title>`) {
+		t.Error("The day with the smallest value should keep the first color stop")
+	}
+
+	invalidMD := `heatmapchart
+colorscale: rainbow
+
+data:
+2025-01-01 | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for unknown color scale, but got none")
+	} else if !strings.Contains(err.Error(), "invalid colorscale value") {
+		t.Errorf("Expected error about invalid color scale, got: %v", err)
+	}
+}
+
+func TestParseCalendarHeatmapOptions(t *testing.T) {
+	calendarMD := `heatmapchart
+title: Commits
+weekstart: monday
+aggregate: max
+
+data:
+2024-12-30 | 2
+2024-12-30 | 5
+2025-01-01 | 1`
+
+	svg, err := ParseMarkdownChart(calendarMD)
+	if err != nil {
+		t.Fatalf("Error parsing calendar heatmap: %v", err)
+	}
+
+	// Duplicate days are aggregated instead of overwritten
+	if !strings.Contains(svg, "<title>Mon, Dec 30, 2024: 5</title>") {
+		t.Error("Calendar heatmap should aggregate values on the same day")
+	}
+
+	if !strings.Contains(svg, "<title>Tue, Dec 31, 2024: no data</title>") {
+		t.Error("Calendar heatmap should mark days without data in the tooltip")
+	}
+
+	// With a Monday week start, December 30 opens the first column
+	first := strings.Index(svg, "<title>")
+	if first < 0 || !strings.HasPrefix(svg[first:], "<title>Mon, Dec 30, 2024") {
+		t.Error("Calendar heatmap weeks should start on Monday")
+	}
+
+	multiYearMD := `heatmapchart
+data:
+2023-02-01 | 1
+2024-06-01 | 2`
+
+	svg, err = ParseMarkdownChart(multiYearMD)
+	if err != nil {
+		t.Fatalf("Error parsing multi-year calendar heatmap: %v", err)
+	}
+
+	if !strings.Contains(svg, ">2023</text>") || !strings.Contains(svg, ">2024</text>") {
+		t.Error("Multi-year calendar heatmap should have one labeled row per year")
+	}
+
+	invalidMD := `heatmapchart
+weekstart: friday
+
+data:
+2025-01-01 | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid week start, but got none")
+	} else if !strings.Contains(err.Error(), "invalid weekstart value") {
+		t.Errorf("Expected error about invalid week start, got: %v", err)
+	}
+}
+
+func TestParsePalettes(t *testing.T) {
+	paletteMD := `barchart
+title: Revenue
+palette: okabe-ito+patterns
+
+series:
+Quarter | North | South | East
+Q1 | 10 | 12 | 8
+Q2 | 14 | 9 | 11`
+
+	svg, err := ParseMarkdownChart(paletteMD)
+	if err != nil {
+		t.Fatalf("Error parsing bar chart with named palette: %v", err)
+	}
+
+	if !strings.Contains(svg, `fill="#E69F00"`) {
+		t.Error("Bar chart should use the first Okabe-Ito color")
+	}
+
+	if !strings.Contains(svg, "<pattern") || !strings.Contains(svg, "url(#gosvgchart-pattern-") {
+		t.Error("Bar chart with '+patterns' should fill series with patterns")
+	}
+
+	// Patterns on their own keep the configured colors
+	patternsMD := `piechart
+colors: #111111, #eeeeee
+patterns: true
+
+data:
+A | 1
+B | 2`
+
+	svg, err = ParseMarkdownChart(patternsMD)
+	if err != nil {
+		t.Fatalf("Error parsing pie chart with patterns: %v", err)
+	}
+
+	if !strings.Contains(svg, `<rect width="8" height="8" fill="#eeeeee"/>`) {
+		t.Error("Pie chart patterns should be drawn over the configured slice colors")
+	}
+
+	invalidMD := `barchart
+palette: rainbow
+
+data:
+A | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for unknown palette, but got none")
+	} else if !strings.Contains(err.Error(), "invalid palette value") {
+		t.Errorf("Expected error about invalid palette, got: %v", err)
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	themeMD := `linechart
+title: Temperature
+theme: solarized
+
+data:
+Mon | 12
+Tue | 15
+Wed | 11`
+
+	svg, err := ParseMarkdownChart(themeMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with theme: %v", err)
+	}
+
+	if !strings.Contains(svg, "--chart-bg: #fdf6e3;") || !strings.Contains(svg, "--chart-bg: #002b36;") {
+		t.Error("Solarized theme should set the light and dark background colors")
+	}
+
+	if !strings.Contains(svg, `stroke="#268bd2" stroke-width="2.5"`) {
+		t.Error("Solarized theme should set the line color and stroke width")
+	}
+
+	printMD := `barchart
+theme: print
+colors: #ff0000
+
+data:
+A | 1
+B | 2`
+
+	svg, err = ParseMarkdownChart(printMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with print theme: %v", err)
+	}
+
+	if strings.Contains(svg, "prefers-color-scheme") {
+		t.Error("Print theme should disable dark mode")
+	}
+
+	if !strings.Contains(svg, "font-family: Georgia") {
+		t.Error("Print theme should set the font family")
+	}
+
+	// Without dark mode the theme's light colors are drawn directly
+	if !strings.Contains(svg, `fill="#000000"`) || !strings.Contains(svg, `stroke="#000000"`) {
+		t.Error("Print theme should set the text and axis colors")
+	}
+
+	// Explicit colors take precedence over the theme
+	if !strings.Contains(svg, `fill="#ff0000"`) {
+		t.Error("Explicit colors should override the theme colors")
+	}
+
+	svg, err = ParseMarkdownChart(`ganttchart
+theme: print
+
+data:
+Design | 2025-01-03 | 2025-02-10`)
+	if err != nil {
+		t.Fatalf("Error parsing gantt chart with print theme: %v", err)
+	}
+	if !strings.Contains(svg, `stroke="#cccccc"`) {
+		t.Error("Print theme should set the grid color")
+	}
+
+	invalidMD := `linechart
+theme: neon
+
+data:
+A | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for unknown theme, but got none")
+	} else if !strings.Contains(err.Error(), "unknown theme") {
+		t.Errorf("Expected error about unknown theme, got: %v", err)
+	}
+}
+
+func TestParseTypography(t *testing.T) {
+	fontMD := `barchart
+title: Revenue
+font: Inter, Helvetica, sans-serif
+fontsizes: 24, 14
+
+data:
+A | 1
+B | 2`
+
+	svg, err := ParseMarkdownChart(fontMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with typography: %v", err)
+	}
+
+	if strings.Contains(svg, `font-family="Arial"`) {
+		t.Error("Text elements should use CSS classes instead of font attributes")
+	}
+
+	if !strings.Contains(svg, `class="chart-title"`) || !strings.Contains(svg, `class="chart-label"`) {
+		t.Error("Text elements should be marked with their typography role")
+	}
+
+	if !strings.Contains(svg, ".chart-title { font-family: Inter, Helvetica, sans-serif; font-size: 24px; font-weight: bold; }") {
+		t.Error("Title style should use the configured font and size")
+	}
+
+	// Sizes that are not given keep their defaults
+	if !strings.Contains(svg, ".chart-tick { font-family: Inter, Helvetica, sans-serif; font-size: 10px;") {
+		t.Error("Tick style should keep the default size")
+	}
+
+	invalidMD := `barchart
+fontsizes: large
+
+data:
+A | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid font sizes, but got none")
+	} else if !strings.Contains(err.Error(), "invalid fontsizes value") {
+		t.Errorf("Expected error about invalid font sizes, got: %v", err)
+	}
+}
+
+func TestParseLongLabels(t *testing.T) {
+	pieMD := `piechart
+title: Market Share
+width: 500
+height: 300
+
+data:
+North America and the Caribbean Islands | 35
+Europe | 28`
+
+	svg, err := ParseMarkdownChart(pieMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with long labels: %v", err)
+	}
+
+	// Labels that don't fit next to the pie are shortened by measured width
+	if !strings.Contains(svg, "…<title>North America and the Caribbean Islands</title>") {
+		t.Error("Long legend label should be truncated with an ellipsis and keep the full label as a tooltip")
+	}
+
+	if !strings.Contains(svg, ">Europe</text>") {
+		t.Error("Short legend label should not be truncated")
+	}
+
+	var rows strings.Builder
+	rows.WriteString("barchart\nwidth: 400\nheight: 300\n\ndata:\n")
+	for i := 1; i <= 40; i++ {
+		rows.WriteString(fmt.Sprintf("Week %d | %d\n", i, i))
+	}
+
+	svg, err = ParseMarkdownChart(rows.String())
+	if err != nil {
+		t.Fatalf("Error parsing chart with many labels: %v", err)
+	}
+
+	// Overlapping x-axis labels are skipped instead of drawn on top of each other
+	if n := strings.Count(svg, ">Week "); n == 0 || n >= 40 {
+		t.Errorf("Expected some but not all of the 40 x-axis labels to be drawn, got %d", n)
+	}
+
+	// A single labelled point of several series sits in the middle of the axis
+	svg, err = ParseMarkdownChart(`linechart
+width: 400
+
+series:
+Month | North | South
+January | 10 | 20`)
+	if err != nil {
+		t.Fatalf("Error parsing one point chart: %v", err)
+	}
+	if !strings.Contains(svg, ">January</text>") {
+		t.Error("Expected the label of the single point")
+	}
+
+	// The margins grow so the labels centered on both ends of the axis stay inside the chart
+	svg, err = ParseMarkdownChart(`linechart
+width: 400
+
+data:
+Q1 | 10
+Q2 | 15
+End of the financial year | 20`)
+	if err != nil {
+		t.Fatalf("Error parsing chart with a long last label: %v", err)
+	}
+	end := strings.Index(svg, ">End of the financial year</text>")
+	var x int
+	if end < 0 {
+		t.Fatal("Expected the long last label to be drawn")
+	} else if _, err := fmt.Sscanf(svg[strings.LastIndex(svg[:end], "<text x=")+len("<text x="):], `"%d"`, &x); err != nil {
+		t.Fatalf("Error reading the position of the last label: %v", err)
+	}
+	if x > 340 { // The label is about 130 pixels wide
+		t.Errorf("Expected the last label to end inside the chart, it is centered at %d", x)
+	}
+}
+
+func TestParseXLabelStrategy(t *testing.T) {
+	rotatedMD := `barchart
+title: Sales by Store
+xlabels: rotate45
+
+data:
+Downtown | 10
+Airport | 12
+Harbor | 8`
+
+	svg, err := ParseMarkdownChart(rotatedMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with xlabels: %v", err)
+	}
+
+	if strings.Count(svg, "rotate(-45") != 3 {
+		t.Error("Expected all x-axis labels to be rotated by 45 degrees")
+	}
+
+	stepMD := `linechart
+xlabels: horizontal
+xlabelstep: 2
+
+data:
+Jan | 1
+Feb | 2
+Mar | 3
+Apr | 4`
+
+	svg, err = ParseMarkdownChart(stepMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with xlabelstep: %v", err)
+	}
+
+	if !strings.Contains(svg, ">Jan</text>") || !strings.Contains(svg, ">Mar</text>") ||
+		strings.Contains(svg, ">Feb</text>") || strings.Contains(svg, ">Apr</text>") {
+		t.Error("Expected every second x-axis label to be drawn")
+	}
+
+	invalidMD := `barchart
+xlabels: diagonal
+
+data:
+A | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid xlabels value, but got none")
+	} else if !strings.Contains(err.Error(), "invalid xlabels value") {
+		t.Errorf("Expected error about invalid xlabels value, got: %v", err)
+	}
+}
+
+func TestParseAxisTitles(t *testing.T) {
+	axisMD := `linechart
+title: API Traffic
+xlabel: Week
+ylabel: Requests/s
+yunit: " rps"
+
+data:
+1 | 120
+2 | 340
+3 | 250`
+
+	svg, err := ParseMarkdownChart(axisMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with axis titles: %v", err)
+	}
+
+	if !strings.Contains(svg, ">Week</text>") {
+		t.Error("Expected x-axis title in output")
+	}
+
+	if !strings.Contains(svg, `transform="rotate(-90`) || !strings.Contains(svg, ">Requests/s</text>") {
+		t.Error("Expected rotated y-axis title in output")
+	}
+
+	if !strings.Contains(svg, ">100 rps</text>") {
+		t.Error("Expected y-axis ticks with the unit suffix")
+	}
+
+	// Charts without axis titles or units keep their plain axes
+	plainMD := `linechart
+data:
+1 | 120
+2 | 340`
+
+	svg, err = ParseMarkdownChart(plainMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart: %v", err)
+	}
+
+	if strings.Contains(svg, `class="chart-tick"`) {
+		t.Error("Expected no y-axis ticks without an axis title or unit")
+	}
+}
+
+func TestParseAnnotations(t *testing.T) {
+	annotatedMD := `linechart
+title: Availability
+annotate: hline 99.9 "SLO"
+annotate: vband Feb Mar "Maintenance"
+annotate: callout Mar "deploy v2.3"
+
+data:
+Jan | 99.95
+Feb | 99.5
+Mar | 99.99
+Apr | 99.97`
+
+	svg, err := ParseMarkdownChart(annotatedMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with annotations: %v", err)
+	}
+
+	if !strings.Contains(svg, `stroke-dasharray="6,4"`) || !strings.Contains(svg, ">SLO</text>") {
+		t.Error("Expected a dashed reference line labeled SLO")
+	}
+
+	if !strings.Contains(svg, `fill-opacity="0.12"`) || !strings.Contains(svg, ">Maintenance</text>") {
+		t.Error("Expected a shaded band labeled Maintenance")
+	}
+
+	if !strings.Contains(svg, ">deploy v2.3</text>") {
+		t.Error("Expected a callout with its text")
+	}
+
+	invalidMD := `barchart
+annotate: hline high "SLO"
+
+data:
+A | 1`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid annotation, but got none")
+	} else if !strings.Contains(err.Error(), "invalid annotate value") {
+		t.Errorf("Expected error about invalid annotation, got: %v", err)
+	}
+}
+
+func TestParseTrendlines(t *testing.T) {
+	trendMD := `linechart
+title: Monthly Sales
+trendline: Online=linear
+movingaverage: sma 2
+fitstats: true
+
+series:
+Month | Online | Retail
+Jan | 10 | 30
+Feb | 14 | 28
+Mar | 19 | 31
+Apr | 25 | 29`
+
+	svg, err := ParseMarkdownChart(trendMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with trendlines: %v", err)
+	}
+
+	if !strings.Contains(svg, ">Online trend</text>") {
+		t.Error("Expected a legend entry for the Online trendline")
+	}
+
+	if !strings.Contains(svg, "slope 5, R² 0.99") {
+		t.Error("Expected the fit parameters in the legend")
+	}
+
+	// Moving averages without a series name apply to every series
+	if !strings.Contains(svg, ">Online SMA 2</text>") || !strings.Contains(svg, ">Retail SMA 2</text>") {
+		t.Error("Expected a moving average for each series")
+	}
+
+	invalidMD := `linechart
+trendline: cubic
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid trendline, but got none")
+	} else if !strings.Contains(err.Error(), "invalid trendline value") {
+		t.Errorf("Expected error about invalid trendline, got: %v", err)
+	}
+
+	// Misspelled series names are reported instead of falling back to the first series
+	_, err = ParseMarkdownChart(strings.Replace(trendMD, "Online=linear", "online=linear", 1))
+	if err == nil || !strings.Contains(err.Error(), "unknown series 'online'") {
+		t.Errorf("Expected error about an unknown series, got: %v", err)
+	}
+
+	// Only polynomial trendlines take a number
+	_, err = ParseMarkdownChart(strings.Replace(trendMD, "Online=linear", "Online=linear 3", 1))
+	if err == nil || !strings.Contains(err.Error(), "take no number") {
+		t.Errorf("Expected error about a count on a linear trendline, got: %v", err)
+	}
+}
+
+func TestParseErrorBounds(t *testing.T) {
+	barMD := `barchart
+title: Conversion Rate
+
+series:
+Variant | Control | Treatment
+Week 1 | 4.1 ± 0.3 | 4.8 [4.2, 5.5]
+Week 2 | 4.0 +/- 0.2 | 5.1 ± 0.4`
+
+	svg, err := ParseMarkdownChart(barMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with error bounds: %v", err)
+	}
+
+	// Each bar gets a whisker with two caps
+	if n := strings.Count(svg, `stroke-width="1.5" fill="none"`); n != 4 {
+		t.Errorf("Expected 4 error bars, got %d", n)
+	}
+
+	lineMD := `linechart
+title: Forecast
+
+data:
+Jan | 10 [8, 12]
+Feb | 12 ± 3
+Mar | 15`
+
+	svg, err = ParseMarkdownChart(lineMD)
+	if err != nil {
+		t.Fatalf("Error parsing line chart with error bounds: %v", err)
+	}
+
+	if !strings.Contains(svg, `fill-opacity="0.2" stroke="none"`) {
+		t.Error("Expected a confidence band around the line")
+	}
+
+	invalidMD := `barchart
+data:
+A | 4 ± wide`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid error bound, but got none")
+	} else if !strings.Contains(err.Error(), "is not a valid number") {
+		t.Errorf("Expected error about the invalid value, got: %v", err)
+	}
+}
+
+func TestParseMissingValues(t *testing.T) {
+	lineMD := `linechart
+title: Sensor Readings
+
+series:
+Hour | Indoor | Outdoor
+1:00 | 20 | 12
+2:00 | - | 11
+3:00 | 22 |
+4:00 | 23 | 14`
+
+	svg, err := ParseMarkdownChart(lineMD)
+	if err != nil {
+		t.Fatalf("Error parsing chart with missing values: %v", err)
+	}
+
+	// Each series breaks into two lines at its missing value
+	if n := strings.Count(svg, `fill="none" stroke=`); n != 4 {
+		t.Errorf("Expected 4 line segments, got %d", n)
+	}
+	if strings.Contains(svg, "NaN") {
+		t.Error("Expected missing values to stay out of the SVG")
+	}
+
+	svg, err = ParseMarkdownChart(strings.Replace(lineMD, "series:", "gaps: connect\n\nseries:", 1))
+	if err != nil {
+		t.Fatalf("Error parsing chart with connected gaps: %v", err)
+	}
+	if n := strings.Count(svg, `fill="none" stroke=`); n != 2 {
+		t.Errorf("Expected 2 connected lines, got %d", n)
+	}
+
+	barMD := `barchart
+title: Sales
+
+data:
+Q1 | 10
+Q2 | -
+Q3 | 30`
+
+	svg, err = ParseMarkdownChart(barMD)
+	if err != nil {
+		t.Fatalf("Error parsing bar chart with missing values: %v", err)
+	}
+
+	// Background and two bars, the missing quarter gets no bar
+	if n := strings.Count(svg, "<rect"); n != 3 {
+		t.Errorf("Expected 3 rects, got %d", n)
+	}
+
+	invalidMD := `linechart
+gaps: bridge
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid gaps value, but got none")
+	} else if !strings.Contains(err.Error(), "invalid gaps value") {
+		t.Errorf("Expected error about invalid gaps value, got: %v", err)
+	}
+}
+
+func TestParseDownsample(t *testing.T) {
+	var data strings.Builder
+	for i := 0; i < 2000; i++ {
+		data.WriteString(fmt.Sprintf("%d | %d\n", i, (i*37)%101))
+	}
+
+	md := "linechart\ntitle: Requests\nwidth: 400\ndownsample: minmax\n\ndata:\n" + data.String()
+	svg, err := ParseMarkdownChart(md)
+	if err != nil {
+		t.Fatalf("Error parsing chart with downsampling: %v", err)
+	}
+
+	// At most two points per pixel column, and no markers on a series this long
+	if n := strings.Count(svg, " L"); n >= 2000 {
+		t.Errorf("Expected the line to be downsampled, got %d segments", n)
+	}
+	if strings.Contains(svg, "<circle") {
+		t.Error("Expected no point markers on a downsampled series")
+	}
+
+	// Without downsampling every point keeps its marker
+	svg, err = ParseMarkdownChart(strings.Replace(md, "downsample: minmax\n", "", 1))
+	if err != nil {
+		t.Fatalf("Error parsing chart without downsampling: %v", err)
+	}
+	if n := strings.Count(svg, "<circle"); n < 2000 {
+		t.Errorf("Expected a marker on every point without downsampling, got %d", n)
+	}
+
+	invalidMD := `linechart
+downsample: fast
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid downsample value, but got none")
+	} else if !strings.Contains(err.Error(), "invalid downsample value") {
+		t.Errorf("Expected error about invalid downsample value, got: %v", err)
+	}
+}
+
+func TestParseInterpolation(t *testing.T) {
+	base := `linechart
+title: Temperature
+%s
+data:
+Mon | 12
+Tue | 18
+Wed | 15
+Thu | 21`
+
+	tests := []struct {
+		config  string
+		command string
+	}{
+		{"", " L"},
+		{"interpolation: monotone\n", " C"},
+		{"curve: catmull-rom\ntension: 0.5\n", " C"},
+		{"interpolation: step-after\n", " L"},
+	}
+	for _, tt := range tests {
+		svg, err := ParseMarkdownChart(fmt.Sprintf(base, tt.config))
+		if err != nil {
+			t.Fatalf("Error parsing chart with %q: %v", tt.config, err)
+		}
+		if !strings.Contains(svg, tt.command) {
+			t.Errorf("Expected %q path commands with %q", tt.command, tt.config)
+		}
+	}
+
+	// Monotone lines are cubic curves, not the old quadratic segments
+	svg, _ := ParseMarkdownChart(fmt.Sprintf(base, "interpolation: monotone\n"))
+	if strings.Contains(svg, " Q") {
+		t.Error("Expected no quadratic segments in a monotone line")
+	}
+
+	invalidMD := `linechart
+interpolation: wiggly
+tension: 2
+
+data:
+A | 1
+B | 2`
+
+	_, err := ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected errors for invalid interpolation and tension, but got none")
+	} else if !strings.Contains(err.Error(), "invalid interpolation value") || !strings.Contains(err.Error(), "invalid tension value") {
+		t.Errorf("Expected errors about interpolation and tension, got: %v", err)
+	}
+}
+
+func TestParseSeriesStyle(t *testing.T) {
+	md := `linechart
+title: Latency
+style: p50 = dash solid, width 2, marker diamond, size 4
+style: p99 = dash 6 2, opacity 0.6, marker none
+
+series:
+Hour | p50 | p95 | p99
+1:00 | 20 | 45 | 90
+2:00 | 22 | 48 | 95`
+
+	svg, err := ParseMarkdownChart(md)
+	if err != nil {
+		t.Fatalf("Error parsing chart with series styles: %v", err)
+	}
+
+	if !strings.Contains(svg, `stroke-width="2"/>`) {
+		t.Error("Expected a solid 2px line for p50")
+	}
+	if !strings.Contains(svg, `stroke-dasharray="6,2" opacity="0.6"`) {
+		t.Error("Expected a translucent dashed line for p99")
+	}
+	// p95 has no style and cycles to the second default: dashed with square markers
+	if !strings.Contains(svg, `stroke-dasharray="8,4"`) || !strings.Contains(svg, "<rect x=") {
+		t.Error("Expected default dash and square markers for p95")
+	}
+	if strings.Contains(svg, "<circle") {
+		t.Error("Expected no circle markers")
+	}
+
+	invalidMD := `linechart
+style: p50 = marker star
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected error for invalid marker, but got none")
+	} else if !strings.Contains(err.Error(), "invalid style value") {
+		t.Errorf("Expected error about invalid style, got: %v", err)
+	}
+}
+
+func TestParseStackModes(t *testing.T) {
+	percentMD := `barchart
+title: Market Share
+stackmode: percent
+
+series:
+Quarter | North | South
+Q1 | 30 | 10
+Q2 | 25 | 25`
+
+	svg, err := ParseMarkdownChart(percentMD)
+	if err != nil {
+		t.Fatalf("Error parsing percent stacked chart: %v", err)
+	}
+
+	// Segments are labeled with their share of the bar
+	for _, label := range []string{">75%<", ">25%<", ">50%<"} {
+		if !strings.Contains(svg, label) {
+			t.Errorf("Expected segment label %s", label)
+		}
+	}
+
+	// Trendlines are in the units of the data, so percent stacks leave them out of the scale and the plot
+	trendSVG, err := ParseMarkdownChart(strings.Replace(percentMD, "stackmode: percent", "stackmode: percent\ntrendline: North=linear", 1))
+	if err != nil {
+		t.Fatalf("Error parsing percent stacked chart with a trendline: %v", err)
+	}
+	if trendSVG != svg {
+		t.Error("Expected a trendline to leave a percent stacked chart unchanged")
+	}
+
+	groupedMD := `barchart
+title: Sales by Product
+stackgroup: 2024 = Phones 2024, Laptops 2024
+stackgroup: 2025 = Phones 2025, Laptops 2025
+
+series:
+Region | Phones 2024 | Laptops 2024 | Phones 2025 | Laptops 2025
+North | 30 | 20 | 35 | 25
+South | 25 | 15 | 30 | 20`
+
+	svg, err = ParseMarkdownChart(groupedMD)
+	if err != nil {
+		t.Fatalf("Error parsing grouped stacked chart: %v", err)
+	}
+
+	// Two stacks per region, each named after its group and topped by its total
+	if n := strings.Count(svg, ">2024<"); n != 2 {
+		t.Errorf("Expected 2 stacks named 2024, got %d", n)
+	}
+	if !strings.Contains(svg, ">60<") {
+		t.Error("Expected the total of the North 2025 stack")
+	}
+
+	invalidMD := `barchart
+stackmode: layered
+stackgroup: 2024
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected errors for invalid stack settings, but got none")
+	} else if !strings.Contains(err.Error(), "invalid stackmode value") || !strings.Contains(err.Error(), "invalid stackgroup value") {
+		t.Errorf("Expected errors about stackmode and stackgroup, got: %v", err)
+	}
+}
+
+func TestParsePieOptions(t *testing.T) {
+	pieMD := `piechart
+title: Browser Share
+labels: outside
+other: 5%
+otherlabel: Rest
+sort: desc
+startangle: -90
+explode: Firefox
+
+data:
+Safari | 20
+Chrome | 60
+Firefox | 12
+Edge | 4
+Opera | 3
+Brave | 1`
+
+	svg, err := ParseMarkdownChart(pieMD)
+	if err != nil {
+		t.Fatalf("Error parsing pie chart options: %v", err)
+	}
+
+	// Slices below 5% are grouped, outside labels carry the name and share
+	if !strings.Contains(svg, ">Rest 8.0%<") {
+		t.Error("Expected an outside label for the grouped slice")
+	}
+	if strings.Contains(svg, ">Opera") {
+		t.Error("Expected Opera to be grouped into the Rest slice")
+	}
+
+	// Sorted largest first
+	if strings.Index(svg, ">Chrome 60.0%<") > strings.Index(svg, ">Safari 20.0%<") {
+		t.Error("Expected Chrome before Safari when sorted descending")
+	}
+
+	// Leader lines of exploded slices bend outside the slice, not back across it
+	svg, err = ParseMarkdownChart(`piechart
+width: 1200
+height: 900
+labels: outside
+startangle: -30
+explode: A
+
+data:
+A | 10
+B | 10
+C | 10
+D | 10
+E | 10
+F | 10`)
+	if err != nil {
+		t.Fatalf("Error parsing pie chart with an exploded slice: %v", err)
+	}
+	label := strings.Index(svg, ">A 16.7%<")
+	var anchorX, anchorY, elbowX, elbowY float64
+	if label < 0 {
+		t.Fatal("Expected an outside label for the exploded slice")
+	} else if _, err := fmt.Sscanf(svg[strings.LastIndex(svg[:label], `<path d="`)+len(`<path d="`):], "M%f,%f L%f,%f", &anchorX, &anchorY, &elbowX, &elbowY); err != nil {
+		t.Fatalf("Error reading the leader line of the exploded slice: %v", err)
+	}
+	if elbowX < anchorX {
+		t.Errorf("Expected the leader line of the exploded slice to run outward, got anchor %.1f and elbow %.1f", anchorX, elbowX)
+	}
+
+	// A single slice keeps its highlight
+	svg, err = ParseMarkdownChart("piechart\nhighlight: label A, opacity 0.5\n\ndata:\nA | 10")
+	if err != nil {
+		t.Fatalf("Error parsing single slice pie chart: %v", err)
+	}
+	if !strings.Contains(svg, `opacity="0.5"`) {
+		t.Error("Expected the highlight opacity on the single slice")
+	}
+
+	topMD := `piechart
+other: top 2
+
+data:
+A | 50
+B | 30
+C | 10
+D | 10`
+
+	svg, err = ParseMarkdownChart(topMD)
+	if err != nil {
+		t.Fatalf("Error parsing pie chart with top N: %v", err)
+	}
+	if !strings.Contains(svg, ">Other<") {
+		t.Error("Expected an Other legend entry for slices beyond the top 2")
+	}
+
+	invalidMD := `piechart
+labels: around
+other: many
+sort: random
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected errors for invalid pie settings, but got none")
+	} else {
+		for _, msg := range []string{"invalid labels value", "invalid other value", "invalid sort value"} {
+			if !strings.Contains(err.Error(), msg) {
+				t.Errorf("Expected error containing %q, got: %v", msg, err)
+			}
+		}
+	}
+}
+
+func TestParseHighlight(t *testing.T) {
+	barMD := `barchart
+title: Monthly Errors
+highlight: above 40, color #e74c3c
+highlight: label Mar, color #f39c12, emphasis
+
+data:
+Jan | 10
+Feb | 50
+Mar | 20`
+
+	svg, err := ParseMarkdownChart(barMD)
+	if err != nil {
+		t.Fatalf("Error parsing highlight rules: %v", err)
+	}
+
+	// The bar above 40 is red, the current month is orange and outlined
+	if !strings.Contains(svg, `fill="#e74c3c"`) {
+		t.Error("Expected the value above 40 to be red")
+	}
+	if !strings.Contains(svg, `fill="#f39c12" stroke=`) {
+		t.Error("Expected the Mar bar to be orange with an outline")
+	}
+
+	lineMD := `linechart
+highlight: Outdoor = below 0, color blue
+
+series:
+Day | Indoor | Outdoor
+Mon | 20 | 5
+Tue | 21 | -3`
+
+	svg, err = ParseMarkdownChart(lineMD)
+	if err != nil {
+		t.Fatalf("Error parsing series highlight: %v", err)
+	}
+	if n := strings.Count(svg, `fill="blue"`); n != 1 {
+		t.Errorf("Expected 1 blue marker for the Outdoor value below 0, got %d", n)
+	}
+
+	invalidMD := `barchart
+highlight: over 40, color red
+highlight: above 40, shade red
+
+data:
+A | 1
+B | 2`
+
+	_, err = ParseMarkdownChart(invalidMD)
+	if err == nil {
+		t.Error("Expected errors for invalid highlight rules, but got none")
+	} else if n := strings.Count(err.Error(), "invalid highlight value"); n != 2 {
+		t.Errorf("Expected 2 highlight errors, got: %v", err)
+	}
+}
+
+func TestParseLinks(t *testing.T) {
+	barMD := `barchart
+title: Sales
+url: North = https://example.com/sales?region={series}&month={label}
+linktarget: _blank
+dataattributes: true
+
+series:
+Month | North | South
+Jan | 10 | 20
+Feb | 15 | 25`
+
+	svg, err := ParseMarkdownChart(barMD)
+	if err != nil {
+		t.Fatalf("Error parsing links: %v", err)
+	}
+
+	// Only the North bars link, the query string keeps its "="
+	if n := strings.Count(svg, `<a href="https://example.com/sales?region=North&amp;month=`); n != 2 {
+		t.Errorf("Expected 2 links for the North bars, got %d", n)
+	}
+	if !strings.Contains(svg, `target="_blank"`) {
+		t.Error("Expected links to open in a new window")
+	}
+	if !strings.Contains(svg, `data-series="South" data-label="Feb" data-value="25"`) {
+		t.Error("Expected data attributes on the South bars")
+	}
+
+	pieMD := `piechart
+url: /browsers/{label}?id=1
+
+data:
+Chrome | 60
+Safari | 40`
+
+	svg, err = ParseMarkdownChart(pieMD)
+	if err != nil {
+		t.Fatalf("Error parsing pie links: %v", err)
+	}
+	if !strings.Contains(svg, `<a href="/browsers/Safari?id=1">`) {
+		t.Error("Expected a link for the Safari slice")
+	}
+	if strings.Contains(svg, "data-label") {
+		t.Error("Expected no data attributes unless enabled")
+	}
+	// Labels in the path are path escaped, so spaces don't turn into "+"
+	svg, err = ParseMarkdownChart(strings.Replace(pieMD, "Safari | 40", "Mobile Safari | 40", 1))
+	if err != nil {
+		t.Fatalf("Error parsing pie links: %v", err)
+	}
+	if !strings.Contains(svg, `<a href="/browsers/Mobile%20Safari?id=1">`) {
+		t.Error("Expected a path escaped label in the link")
+	}
+
+	// Script and data links are rejected, since the SVG is inlined into pages
+	for _, link := range []string{"javascript:alert(document.cookie)", "North = JavaScript:alert(1)", "data:text/html,hi"} {
+		_, err = ParseMarkdownChart(strings.Replace(barMD, "North = https://example.com/sales?region={series}&month={label}", link, 1))
+		if err == nil || !strings.Contains(err.Error(), "line 3: invalid url value") || !strings.Contains(err.Error(), "is not allowed") {
+			t.Errorf("Expected error about the link scheme of %q, got: %v", link, err)
+		}
+	}
+
+	// A series prefix that names no series is reported rather than linking nothing
+	_, err = ParseMarkdownChart(strings.Replace(barMD, "url: North =", "url: West =", 1))
+	if err == nil || !strings.Contains(err.Error(), "unknown series 'West' in url") {
+		t.Errorf("Expected error about the unknown series of a link, got: %v", err)
+	}
+
+	// Sankey flows and Gantt tasks link and carry data attributes like other marks
+	sankeyMD := `sankeychart
+url: /flows?from={source}&to={target}
+dataattributes: true
+
+data:
+Search > Home | 120
+Home > Checkout | 40`
+
+	svg, err = ParseMarkdownChart(sankeyMD)
+	if err != nil {
+		t.Fatalf("Error parsing sankey links: %v", err)
+	}
+	if !strings.Contains(svg, `<a href="/flows?from=Home&amp;to=Checkout">`) {
+		t.Error("Expected a link for the Home to Checkout flow")
+	}
+	if !strings.Contains(svg, `data-source="Search" data-target="Home"`) {
+		t.Error("Expected data attributes on the Search to Home flow")
+	}
+
+	ganttMD := `ganttchart
+url: /tasks/{task}
+dataattributes: true
+
+data:
+Design | 2025-01-03 | 2025-02-10 | 40%
+Launch | 2025-03-20`
+
+	svg, err = ParseMarkdownChart(ganttMD)
+	if err != nil {
+		t.Fatalf("Error parsing gantt links: %v", err)
+	}
+	if !strings.Contains(svg, `<a href="/tasks/Design">`) || !strings.Contains(svg, `<a href="/tasks/Launch">`) {
+		t.Error("Expected links for the Design task and the Launch milestone")
+	}
+	if !strings.Contains(svg, `data-start="2025-01-03" data-end="2025-02-10" data-progress="40%"`) {
+		t.Error("Expected data attributes on the Design task")
+	}
+}
+
+func TestParseTooltips(t *testing.T) {
+	lineMD := `linechart
+title: Traffic
+tooltip: {series}: {value:si} on {label}
+hover: true
+
+series:
+Day | Visits | Signups
+Mon | 12500 | 40
+Tue | 14200 | 52`
+
+	svg, err := ParseMarkdownChart(lineMD)
+	if err != nil {
+		t.Fatalf("Error parsing tooltips: %v", err)
+	}
+
+	// Every point gets a tooltip, abbreviated with SI suffixes
+	if !strings.Contains(svg, "<title>Visits: 12.5k on Mon</title>") {
+		t.Error("Expected an SI formatted tooltip for Visits on Mon")
+	}
+	if !strings.Contains(svg, "<title>Signups: 52 on Tue</title>") {
+		t.Error("Expected a tooltip for Signups on Tue")
+	}
+
+	// Hover highlighting is CSS only
+	if !strings.Contains(svg, `class="chart-mark"`) || !strings.Contains(svg, ":hover") {
+		t.Error("Expected marks with hover highlighting styles")
+	}
+	if strings.Contains(svg, "<script") {
+		t.Error("Expected no script in the output")
+	}
+
+	pieMD := `piechart
+tooltip: {label}: {percent:.0f}%
+
+data:
+Chrome | 75
+Safari | 25`
+
+	svg, err = ParseMarkdownChart(pieMD)
+	if err != nil {
+		t.Fatalf("Error parsing pie tooltips: %v", err)
+	}
+	if !strings.Contains(svg, "<title>Safari: 25%</title>") {
+		t.Error("Expected a percentage tooltip for the Safari slice")
+	}
+
+	_, err = ParseMarkdownChart("barchart\nhover: sometimes\n\ndata:\nA | 1")
+	if err == nil || !strings.Contains(err.Error(), "invalid hover value") {
+		t.Errorf("Expected an error about the hover value, got: %v", err)
+	}
+
+	// Sankey flows and Gantt tasks fill in their own fields and take part in hover highlighting
+	sankeyMD := `sankeychart
+tooltip: {source} to {target}: {value}
+hover: true
+
+data:
+Search > Home | 60
+Home > Signup | 20`
+
+	svg, err = ParseMarkdownChart(sankeyMD)
+	if err != nil {
+		t.Fatalf("Error parsing sankey tooltips: %v", err)
+	}
+	if !strings.Contains(svg, `class="chart-mark"><title>Search to Home: 60</title>`) {
+		t.Error("Expected a templated tooltip on the Search to Home flow")
+	}
+
+	ganttMD := `ganttchart
+tooltip: {task} ends {end}, {progress} done
+hover: true
+
+data:
+Design | 2025-01-03 | 2025-02-10 | 40%`
+
+	svg, err = ParseMarkdownChart(ganttMD)
+	if err != nil {
+		t.Fatalf("Error parsing gantt tooltips: %v", err)
+	}
+	if !strings.Contains(svg, `<g class="chart-mark"><title>Design ends 2025-02-10, 40% done</title>`) {
+		t.Error("Expected a templated tooltip on the Design task")
+	}
+}
+
+func TestParseInteractive(t *testing.T) {
+	md := `linechart
+title: Traffic
+interactive: true
+
+series:
+Day | Visits | Signups
+Mon | 12500 | 40
+Tue | 14200 | 52`
+
+	svg, err := ParseMarkdownChart(md)
+	if err != nil {
+		t.Fatalf("Error parsing interactive chart: %v", err)
+	}
+
+	// The script reads the data attributes of the marks and the legend entries
+	if !strings.Contains(svg, "<script>") {
+		t.Error("Expected an embedded script")
+	}
+	if !strings.Contains(svg, `data-series="Signups" data-label="Tue" data-value="52"`) {
+		t.Error("Expected data attributes on the points")
+	}
+	if !strings.Contains(svg, `class="chart-legend-item" data-series="Visits"`) {
+		t.Error("Expected a toggleable legend entry for Visits")
+	}
+
+	// Charts are script-free by default
+	svg, err = ParseMarkdownChart(strings.Replace(md, "interactive: true\n", "", 1))
+	if err != nil {
+		t.Fatalf("Error parsing chart: %v", err)
+	}
+	if strings.Contains(svg, "<script") || strings.Contains(svg, "data-value") {
+		t.Error("Expected no script or data attributes by default")
+	}
+
+	_, err = ParseMarkdownChart(strings.Replace(md, "interactive: true", "interactive: maybe", 1))
+	if err == nil || !strings.Contains(err.Error(), "invalid interactive value") {
+		t.Errorf("Expected an invalid interactive value error, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package gosvgchart
+
+import "math"
+
+// Gap modes for missing values in line charts
+const (
+	GapBreak       = "break"       // End the line before a missing value and restart it after
+	GapConnect     = "connect"     // Draw the line straight across missing values
+	GapInterpolate = "interpolate" // Fill missing values between two known values linearly
+)
+
+// Missing returns the marker for a missing data value, which is NaN
+// Bars and points skip missing values, lines break at them and stacks count them as zero
+func Missing() float64 {
+	return math.NaN()
+}
+
+// IsMissing reports whether a data value is missing
+func IsMissing(v float64) bool {
+	return math.IsNaN(v)
+}
+
+// SetGapMode sets how lines handle missing values: "break" (default), "connect" or "interpolate"
+func (c *LineChart) SetGapMode(mode string) *LineChart {
+	c.GapMode = mode
+	return c
+}
+
+// valueOrZero returns a value, or zero when it is missing
+func valueOrZero(v float64) float64 {
+	if IsMissing(v) {
+		return 0
+	}
+	return v
+}
+
+// gapSegments splits the indexes of a series into the runs drawn as connected lines
+// With GapInterpolate the returned values have missing values between two known values filled in
+func gapSegments(data []float64, mode string) ([]float64, [][]int) {
+	values := data
+	if mode == GapInterpolate {
+		values = interpolateMissing(data)
+	}
+
+	var segments [][]int
+	var current []int
+	for i, v := range values {
+		if IsMissing(v) {
+			if mode != GapConnect && len(current) > 0 {
+				segments = append(segments, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, i)
+	}
+	if len(current) > 0 {
+		segments = append(segments, current)
+	}
+	return values, segments
+}
+
+// interpolateMissing returns a copy of data with missing values between two known values
+// replaced by linear interpolation; leading and trailing missing values stay missing
+func interpolateMissing(data []float64) []float64 {
+	values := append([]float64(nil), data...)
+	last := -1
+	for i, v := range values {
+		if IsMissing(v) {
+			continue
+		}
+		if last >= 0 && i-last > 1 {
+			for j := last + 1; j < i; j++ {
+				t := float64(j-last) / float64(i-last)
+				values[j] = values[last] + (v-values[last])*t
+			}
+		}
+		last = i
+	}
+	return values
+}
@@ -0,0 +1,382 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Overlay kinds
+const (
+	TrendLinear      = "linear"      // Least squares line
+	TrendPolynomial  = "polynomial"  // Least squares polynomial of Degree
+	TrendExponential = "exponential" // y = a·e^(bx), fitted to the positive values
+	MovingAverageSMA = "sma"         // Simple moving average over Window points
+	MovingAverageEMA = "ema"         // Exponential moving average with a span of Window points
+)
+
+// Overlay is a trendline or moving average computed from a series and drawn over it
+type Overlay struct {
+	Kind   string // One of the Trend* or MovingAverage* kinds
+	Series string // Name of the series, the first series or the single series data when empty; unknown names draw nothing
+	Degree int    // Degree of a polynomial trend, 2 when zero
+	Window int    // Number of points of a moving average, 3 when zero
+}
+
+// AddOverlay adds a trendline or moving average to a line or bar chart
+func (chart *BaseChart) AddOverlay(overlay Overlay) *BaseChart {
+	chart.Overlays = append(chart.Overlays, overlay)
+	return chart
+}
+
+// AddTrendline adds a "linear", "polynomial" (quadratic) or "exponential" regression line for a series
+func (chart *BaseChart) AddTrendline(series, kind string) *BaseChart {
+	return chart.AddOverlay(Overlay{Kind: strings.ToLower(kind), Series: series})
+}
+
+// AddMovingAverage adds an "sma" or "ema" moving average over window points for a series
+func (chart *BaseChart) AddMovingAverage(series, kind string, window int) *BaseChart {
+	return chart.AddOverlay(Overlay{Kind: strings.ToLower(kind), Series: series, Window: window})
+}
+
+// SetShowFitStats shows the slope, growth rate and R² of trendlines in the legend,
+// or next to the line when the chart has no legend
+func (chart *BaseChart) SetShowFitStats(show bool) *BaseChart {
+	chart.ShowFitStats = show
+	return chart
+}
+
+// overlayLine is a computed overlay ready to draw
+type overlayLine struct {
+	name   string
+	color  string
+	dashed bool
+	xs, ys []float64 // Points with fractional category indexes
+	stats  string    // Fit parameters, empty for moving averages
+}
+
+// computeOverlays evaluates the chart's overlays on their series data
+func (chart *BaseChart) computeOverlays() []overlayLine {
+	var lines []overlayLine
+	for _, overlay := range chart.Overlays {
+		index := 0
+		data, name := chart.Data, "Trend"
+		if len(chart.Series) > 0 {
+			if index = chart.seriesIndex(overlay.Series); index < 0 {
+				continue // Overlays of unknown series are not drawn
+			}
+			data, name = chart.Series[index].Data, chart.Series[index].Name
+		}
+		if len(data) < 2 {
+			continue
+		}
+		line := overlayLine{color: chart.seriesColor(index), dashed: true}
+		if len(chart.Series) == 0 && len(chart.Colors) > 0 {
+			line.color = chart.Colors[0]
+		}
+
+		switch overlay.Kind {
+		case TrendLinear, TrendPolynomial:
+			degree := 1
+			if overlay.Kind == TrendPolynomial {
+				degree = overlay.Degree
+				if degree <= 0 {
+					degree = 2
+				}
+			}
+			coefficients, ok := fitPolynomial(data, degree)
+			if !ok {
+				continue
+			}
+			line.xs, line.ys = sampleCurve(len(data), func(x float64) float64 { return evalPolynomial(coefficients, x) })
+			r2 := rSquared(data, func(x float64) float64 { return evalPolynomial(coefficients, x) })
+			if degree == 1 {
+				line.name = name + " trend"
+				line.stats = fmt.Sprintf("slope %.3g, R² %.2f", coefficients[1]+0, r2)
+			} else {
+				line.name = fmt.Sprintf("%s trend (degree %d)", name, degree)
+				line.stats = fmt.Sprintf("R² %.2f", r2)
+			}
+		case TrendExponential:
+			a, b, ok := fitExponential(data)
+			if !ok {
+				continue
+			}
+			curve := func(x float64) float64 { return a * math.Exp(b*x) }
+			line.xs, line.ys = sampleCurve(len(data), curve)
+			line.name = name + " exp. trend"
+			line.stats = fmt.Sprintf("growth %.3g%%, R² %.2f", (math.Exp(b)-1)*100, rSquared(data, curve))
+		case MovingAverageSMA, MovingAverageEMA:
+			window := overlay.Window
+			if window <= 0 {
+				window = 3
+			}
+			line.dashed = false
+			if overlay.Kind == MovingAverageSMA {
+				line.xs, line.ys = simpleMovingAverage(data, window)
+				line.name = fmt.Sprintf("%s SMA %d", name, window)
+			} else {
+				line.xs, line.ys = exponentialMovingAverage(data, window)
+				line.name = fmt.Sprintf("%s EMA %d", name, window)
+			}
+		default:
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// overlayMax returns the largest overlay value, so the value scale can include fitted curves
+func overlayMax(lines []overlayLine) float64 {
+	var maxValue float64
+	for _, line := range lines {
+		for _, y := range line.ys {
+			maxValue = math.Max(maxValue, y)
+		}
+	}
+	return maxValue
+}
+
+// renderOverlays draws the overlay lines, with their fit parameters at the end of the line
+// when the chart has no legend to show them in
+func (chart *BaseChart) renderOverlays(svg *strings.Builder, plot plotArea, lines []overlayLine, labelStats bool) {
+	if plot.maxValue <= 0 {
+		return
+	}
+	for _, line := range lines {
+		if len(line.xs) == 0 {
+			continue
+		}
+		var path strings.Builder
+		for i := range line.xs {
+			command := "L"
+			if i == 0 {
+				command = "M"
+			}
+			x, y := plot.xAt(line.xs[i]), plot.y(math.Max(0, math.Min(line.ys[i], plot.maxValue)))
+			path.WriteString(fmt.Sprintf("%s%.1f,%.1f ", command, x, y))
+		}
+		dash := ` stroke-opacity="0.8"`
+		if line.dashed {
+			dash = ` stroke-dasharray="6,4"`
+		}
+		svg.WriteString(fmt.Sprintf(`<path d="%s" fill="none" stroke="%s" stroke-width="%g"%s%s/>`,
+			strings.TrimSpace(path.String()), line.color, math.Max(1, chart.lineWidth()*2/3), dash, chart.zoomClass()))
+
+		if labelStats && chart.ShowFitStats && line.stats != "" {
+			last := len(line.xs) - 1
+			x := plot.xAt(line.xs[last])
+			y := plot.y(math.Max(0, math.Min(line.ys[last], plot.maxValue)))
+			svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="end" class="chart-tick"%s>%s</text>`,
+				x, y-8, chart.textFill(), escapeText(line.stats)))
+		}
+	}
+}
+
+// renderOverlayLegend adds a legend entry for each overlay below the series entries
+// Fit parameters go on a second line in the tick font
+func (chart *BaseChart) renderOverlayLegend(svg *strings.Builder, legendX, legendY int, lines []overlayLine) {
+	y := legendY
+	for _, line := range lines {
+		dash := ""
+		if line.dashed {
+			dash = ` stroke-dasharray="4,2"`
+		}
+		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="2"%s/>`,
+			legendX, y+8, legendX+15, y+8, line.color, dash))
+		chart.renderLegendText(svg, legendX+25, y+12, line.name, float64(chart.Width-legendX-30))
+		if chart.ShowFitStats && line.stats != "" {
+			stats := chart.truncateText(line.stats, "tick", float64(chart.Width-legendX-30))
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="chart-tick"%s>%s</text>`,
+				legendX+25, y+25, chart.textFill(), escapeText(stats)))
+			y += 13
+		}
+		y += 25
+	}
+}
+
+// xAt returns the horizontal position of a fractional category index
+func (p plotArea) xAt(index float64) float64 {
+	i := int(math.Floor(index))
+	x := p.x(i)
+	if frac := index - float64(i); frac > 0 {
+		x += (p.x(i+1) - x) * frac
+	}
+	return x
+}
+
+// sampleCurve evaluates a fitted curve across n categories, finely enough to draw it smooth
+func sampleCurve(n int, curve func(x float64) float64) ([]float64, []float64) {
+	steps := (n - 1) * 8
+	xs := make([]float64, steps+1)
+	ys := make([]float64, steps+1)
+	for i := range xs {
+		xs[i] = float64(i) / 8
+		ys[i] = curve(xs[i])
+	}
+	return xs, ys
+}
+
+// fitPolynomial returns the least squares coefficients c0..cd of y = c0 + c1·x + … + cd·x^d,
+// with x the index of each value, skipping missing values
+func fitPolynomial(data []float64, degree int) ([]float64, bool) {
+	present := 0
+	for _, y := range data {
+		if !IsMissing(y) {
+			present++
+		}
+	}
+	if degree >= present {
+		degree = present - 1
+	}
+	if degree < 1 {
+		return nil, false
+	}
+	size := degree + 1
+
+	// Normal equations as an augmented matrix
+	matrix := make([][]float64, size)
+	for row := range matrix {
+		matrix[row] = make([]float64, size+1)
+		for x, y := range data {
+			if IsMissing(y) {
+				continue
+			}
+			for col := 0; col < size; col++ {
+				matrix[row][col] += math.Pow(float64(x), float64(row+col))
+			}
+			matrix[row][size] += y * math.Pow(float64(x), float64(row))
+		}
+	}
+
+	// Gaussian elimination with partial pivoting
+	for col := 0; col < size; col++ {
+		pivot := col
+		for row := col + 1; row < size; row++ {
+			if math.Abs(matrix[row][col]) > math.Abs(matrix[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(matrix[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+		for row := col + 1; row < size; row++ {
+			factor := matrix[row][col] / matrix[col][col]
+			for k := col; k <= size; k++ {
+				matrix[row][k] -= factor * matrix[col][k]
+			}
+		}
+	}
+	coefficients := make([]float64, size)
+	for row := size - 1; row >= 0; row-- {
+		sum := matrix[row][size]
+		for k := row + 1; k < size; k++ {
+			sum -= matrix[row][k] * coefficients[k]
+		}
+		coefficients[row] = sum / matrix[row][row]
+	}
+	return coefficients, true
+}
+
+// evalPolynomial evaluates a polynomial given by its coefficients at x
+func evalPolynomial(coefficients []float64, x float64) float64 {
+	var y float64
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = y*x + coefficients[i]
+	}
+	return y
+}
+
+// fitExponential fits y = a·e^(bx) by linear regression on the logarithm of the positive values
+func fitExponential(data []float64) (a, b float64, ok bool) {
+	var n, sumX, sumY, sumXY, sumXX float64
+	for x, y := range data {
+		if IsMissing(y) || y <= 0 {
+			continue
+		}
+		fx, ly := float64(x), math.Log(y)
+		n++
+		sumX += fx
+		sumY += ly
+		sumXY += fx * ly
+		sumXX += fx * fx
+	}
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator == 0 {
+		return 0, 0, false
+	}
+	b = (n*sumXY - sumX*sumY) / denominator
+	a = math.Exp((sumY - b*sumX) / n)
+	return a, b, true
+}
+
+// rSquared returns the coefficient of determination of a fitted curve, skipping missing values
+func rSquared(data []float64, curve func(x float64) float64) float64 {
+	var mean, n float64
+	for _, y := range data {
+		if !IsMissing(y) {
+			mean += y
+			n++
+		}
+	}
+	mean /= n
+	var residual, total float64
+	for x, y := range data {
+		if IsMissing(y) {
+			continue
+		}
+		residual += math.Pow(y-curve(float64(x)), 2)
+		total += math.Pow(y-mean, 2)
+	}
+	if total == 0 {
+		return 1
+	}
+	return 1 - residual/total
+}
+
+// simpleMovingAverage returns the mean of each window of values, starting at the first full window
+// Windows with a missing value are left out
+func simpleMovingAverage(data []float64, window int) ([]float64, []float64) {
+	var xs, ys []float64
+	var sum float64
+	missing := 0
+	for i, y := range data {
+		if IsMissing(y) {
+			missing++
+		} else {
+			sum += y
+		}
+		if i >= window {
+			if IsMissing(data[i-window]) {
+				missing--
+			} else {
+				sum -= data[i-window]
+			}
+		}
+		if i >= window-1 && missing == 0 {
+			xs = append(xs, float64(i))
+			ys = append(ys, sum/float64(window))
+		}
+	}
+	return xs, ys
+}
+
+// exponentialMovingAverage returns the exponential moving average with smoothing 2/(window+1)
+// Missing values are left out and the average carries over them
+func exponentialMovingAverage(data []float64, window int) ([]float64, []float64) {
+	alpha := 2 / float64(window+1)
+	var xs, ys []float64
+	for i, y := range data {
+		if IsMissing(y) {
+			continue
+		}
+		if len(ys) > 0 {
+			y = alpha*y + (1-alpha)*ys[len(ys)-1]
+		}
+		xs = append(xs, float64(i))
+		ys = append(ys, y)
+	}
+	return xs, ys
+}
@@ -0,0 +1,115 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// namedPalettes contains qualitative palettes for series and slices
+// Okabe-Ito and viridis stay distinguishable with the common forms of color blindness
+var namedPalettes = map[string][]string{
+	"okabe-ito": {"#E69F00", "#56B4E9", "#009E73", "#F0E442", "#0072B2", "#D55E00", "#CC79A7", "#000000"},
+	"tableau10": {"#4E79A7", "#F28E2B", "#E15759", "#76B7B2", "#59A14F", "#EDC948", "#B07AA1", "#FF9DA7", "#9C755F", "#BAB0AC"},
+	"set1":      {"#E41A1C", "#377EB8", "#4DAF4A", "#984EA3", "#FF7F00", "#FFFF33", "#A65628", "#F781BF", "#999999"},
+	"set2":      {"#66C2A5", "#FC8D62", "#8DA0CB", "#E78AC3", "#A6D854", "#FFD92F", "#E5C494", "#B3B3B3"},
+	"set3":      {"#8DD3C7", "#FFFFB3", "#BEBADA", "#FB8072", "#80B1D3", "#FDB462", "#B3DE69", "#FCCDE5", "#D9D9D9", "#BC80BD", "#CCEBC5", "#FFED6F"},
+	"dark2":     {"#1B9E77", "#D95F02", "#7570B3", "#E7298A", "#66A61E", "#E6AB02", "#A6761D", "#666666"},
+	"paired":    {"#A6CEE3", "#1F78B4", "#B2DF8A", "#33A02C", "#FB9A99", "#E31A1C", "#FDBF6F", "#FF7F00", "#CAB2D6", "#6A3D9A", "#FFFF99", "#B15928"},
+	"accent":    {"#7FC97F", "#BEAED4", "#FDC086", "#FFFF99", "#386CB0", "#F0027F", "#BF5B17", "#666666"},
+	"pastel1":   {"#FBB4AE", "#B3CDE3", "#CCEBC5", "#DECBE4", "#FED9A6", "#FFFFCC", "#E5D8BD", "#FDDAEC", "#F2F2F2"},
+	"viridis":   {"#440154", "#46327E", "#365C8D", "#277F8E", "#1FA187", "#4AC16D", "#A0DA39", "#FDE725"},
+}
+
+// autoPalette is used by the "auto" palette and for series without configured colors
+// It holds the Okabe-Ito colors, ordered so neighbors differ in lightness as well as hue and no
+// red sits next to a green, with gray in place of black, which would vanish in dark mode
+var autoPalette = []string{"#0072B2", "#E69F00", "#56B4E9", "#D55E00", "#CC79A7", "#009E73", "#F0E442", "#999999"}
+
+// NamedPalette returns a copy of a built-in palette by name
+// Matching ignores case, spaces, hyphens and underscores, so "Okabe Ito" finds "okabe-ito"
+func NamedPalette(name string) ([]string, bool) {
+	key := normalizePaletteName(name)
+	for paletteName, colors := range namedPalettes {
+		if normalizePaletteName(paletteName) == key {
+			return append([]string(nil), colors...), true
+		}
+	}
+	return nil, false
+}
+
+// PaletteNames returns the names of the built-in palettes in alphabetical order
+func PaletteNames() []string {
+	names := make([]string, 0, len(namedPalettes))
+	for name := range namedPalettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizePaletteName lowercases a palette name and drops separators
+func normalizePaletteName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// patternKinds lists the pattern fills in the order they are assigned to series and slices
+// The first series stays solid so a single series chart looks unchanged
+var patternKinds = []string{"solid", "hatch", "dots", "crosshatch", "backhatch", "horizontal", "vertical"}
+
+// patternFills collects the pattern definitions used while rendering one chart
+type patternFills struct {
+	enabled bool
+	defs    strings.Builder
+	seen    map[string]bool
+}
+
+// newPatternFills returns a pattern collector, which hands out plain colors unless patterns are enabled
+func (chart *BaseChart) newPatternFills() *patternFills {
+	return &patternFills{enabled: chart.Patterns, seen: make(map[string]bool)}
+}
+
+// fill returns the fill value for the mark at index: the color itself, or a reference to a
+// pattern drawn over that color when patterns are enabled
+func (p *patternFills) fill(index int, color string) string {
+	if !p.enabled {
+		return color
+	}
+	kind := patternKinds[index%len(patternKinds)]
+	if kind == "solid" {
+		return color
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(kind + color))
+	id := fmt.Sprintf("gosvgchart-pattern-%08x", h.Sum32())
+	if !p.seen[id] {
+		p.seen[id] = true
+		mark := contrastTextColor(color)
+		p.defs.WriteString(fmt.Sprintf(`<pattern id="%s" patternUnits="userSpaceOnUse" width="8" height="8"><rect width="8" height="8" fill="%s"/>`, id, color))
+		switch kind {
+		case "hatch":
+			p.defs.WriteString(fmt.Sprintf(`<path d="M-2,2 l4,-4 M0,8 l8,-8 M6,10 l4,-4" stroke="%s" stroke-width="1.5" stroke-opacity="0.7"/>`, mark))
+		case "backhatch":
+			p.defs.WriteString(fmt.Sprintf(`<path d="M-2,6 l4,4 M0,0 l8,8 M6,-2 l4,4" stroke="%s" stroke-width="1.5" stroke-opacity="0.7"/>`, mark))
+		case "crosshatch":
+			p.defs.WriteString(fmt.Sprintf(`<path d="M-2,2 l4,-4 M0,8 l8,-8 M6,10 l4,-4 M-2,6 l4,4 M0,0 l8,8 M6,-2 l4,4" stroke="%s" stroke-width="1" stroke-opacity="0.7"/>`, mark))
+		case "dots":
+			p.defs.WriteString(fmt.Sprintf(`<circle cx="2" cy="2" r="1.5" fill="%s" fill-opacity="0.7"/><circle cx="6" cy="6" r="1.5" fill="%s" fill-opacity="0.7"/>`, mark, mark))
+		case "horizontal":
+			p.defs.WriteString(fmt.Sprintf(`<path d="M0,4 h8" stroke="%s" stroke-width="2" stroke-opacity="0.7"/>`, mark))
+		case "vertical":
+			p.defs.WriteString(fmt.Sprintf(`<path d="M4,0 v8" stroke="%s" stroke-width="2" stroke-opacity="0.7"/>`, mark))
+		}
+		p.defs.WriteString(`</pattern>`)
+	}
+	return fmt.Sprintf("url(#%s)", id)
+}
+
+// writeDefs writes the collected pattern definitions, if any were used
+func (p *patternFills) writeDefs(svg *strings.Builder) {
+	if p.defs.Len() > 0 {
+		svg.WriteString("<defs>" + p.defs.String() + "</defs>")
+	}
+}
@@ -0,0 +1,220 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Pie chart label modes
+const (
+	PieLabelsInside  = "inside"  // Percentages inside the slices, names in the legend
+	PieLabelsOutside = "outside" // Names and percentages outside the pie with leader lines
+)
+
+// Pie chart slice orders
+const (
+	SortNone       = ""     // Slices in data order
+	SortDescending = "desc" // Largest slice first
+	SortAscending  = "asc"  // Smallest slice first
+)
+
+// otherColor is the fill of the slice that collects the small slices
+const otherColor = "#9e9e9e"
+
+// SetLabelMode sets where slice labels go: "inside" (default) or "outside" with leader lines
+func (c *PieChart) SetLabelMode(mode string) *PieChart {
+	c.LabelMode = mode
+	return c
+}
+
+// SetOtherThreshold groups slices below a percentage of the total into one "Other" slice
+func (c *PieChart) SetOtherThreshold(percent float64) *PieChart {
+	c.OtherThreshold = percent
+	return c
+}
+
+// SetOtherTopN keeps the n largest slices and groups the rest into one "Other" slice
+func (c *PieChart) SetOtherTopN(n int) *PieChart {
+	c.OtherTopN = n
+	return c
+}
+
+// SetOtherLabel sets the label of the slice that groups small slices, "Other" by default
+func (c *PieChart) SetOtherLabel(label string) *PieChart {
+	c.OtherLabel = label
+	return c
+}
+
+// SetSortSlices orders the slices "desc" (largest first), "asc" or "" (data order)
+func (c *PieChart) SetSortSlices(order string) *PieChart {
+	c.SortSlices = order
+	return c
+}
+
+// SetStartAngle sets where the first slice starts, in degrees clockwise from the
+// 3 o'clock position; -90 starts at 12 o'clock
+func (c *PieChart) SetStartAngle(degrees float64) *PieChart {
+	c.StartAngle = degrees
+	return c
+}
+
+// ExplodeSlices pulls the slices with these labels out of the pie
+func (c *PieChart) ExplodeSlices(labels ...string) *PieChart {
+	c.Exploded = append(c.Exploded, labels...)
+	return c
+}
+
+// pieSlice is a slice as drawn, after sorting and grouping
+type pieSlice struct {
+	label string
+	value float64
+	index int    // Index of the data value, which picks the color and pattern
+	color string // Fixed color, for the "Other" slice
+}
+
+// pieSlices returns the slices to draw: missing and non-positive values are left out,
+// the rest sorted and small slices grouped into "Other"
+func (c *PieChart) pieSlices() []pieSlice {
+	var slices []pieSlice
+	var total float64
+	for i, v := range c.Data {
+		if IsMissing(v) || v <= 0 {
+			continue
+		}
+		label := ""
+		if i < len(c.Labels) {
+			label = c.Labels[i]
+		}
+		slices = append(slices, pieSlice{label: label, value: v, index: i})
+		total += v
+	}
+
+	// Keep the largest slices and group the rest, the "Other" slice always goes last
+	if c.OtherThreshold > 0 || c.OtherTopN > 0 {
+		sort.SliceStable(slices, func(a, b int) bool { return slices[a].value > slices[b].value })
+		var kept, small []pieSlice
+		for k, slice := range slices {
+			if (c.OtherTopN > 0 && k >= c.OtherTopN) || slice.value/total*100 < c.OtherThreshold {
+				small = append(small, slice)
+			} else {
+				kept = append(kept, slice)
+			}
+		}
+
+		// A single small slice is clearer under its own name
+		if len(small) > 1 {
+			other := pieSlice{label: c.OtherLabel, index: -1, color: otherColor}
+			if other.label == "" {
+				other.label = "Other"
+			}
+			for _, slice := range small {
+				other.value += slice.value
+			}
+			return append(c.orderSlices(kept), other)
+		}
+	}
+	return c.orderSlices(slices)
+}
+
+// orderSlices sorts slices by size when a sort order is set, and back into data order otherwise
+func (c *PieChart) orderSlices(slices []pieSlice) []pieSlice {
+	switch c.SortSlices {
+	case SortDescending:
+		sort.SliceStable(slices, func(a, b int) bool { return slices[a].value > slices[b].value })
+	case SortAscending:
+		sort.SliceStable(slices, func(a, b int) bool { return slices[a].value < slices[b].value })
+	default:
+		sort.SliceStable(slices, func(a, b int) bool { return slices[a].index < slices[b].index })
+	}
+	return slices
+}
+
+// sliceColor returns the color of a slice, or the color of its point style
+func (c *PieChart) sliceColor(slice pieSlice) string {
+	if slice.color != "" {
+		return slice.color
+	}
+	point, _ := c.pointStyle(0, slice.index)
+	return point.colorOr(c.Colors[slice.index%len(c.Colors)])
+}
+
+// exploded reports whether a slice is pulled out of the pie
+func (c *PieChart) exploded(slice pieSlice) bool {
+	for _, label := range c.Exploded {
+		if label == slice.label {
+			return true
+		}
+	}
+	return false
+}
+
+// pieLabel is an outside label anchored to the edge of its slice
+type pieLabel struct {
+	text     string
+	full     string  // Untruncated text for the tooltip
+	anchorX  float64 // Point on the slice edge the leader line starts from
+	anchorY  float64
+	y        float64 // Label baseline after collision avoidance
+	right    bool    // Label is right of the pie
+	maxWidth float64
+}
+
+// layoutPieLabels spreads the labels on each side of the pie vertically so they keep
+// at least one line of space between them and stay within top and bottom
+func layoutPieLabels(labels []pieLabel, lineHeight, top, bottom float64) {
+	for _, right := range []bool{false, true} {
+		var side []*pieLabel
+		for k := range labels {
+			if labels[k].right == right {
+				side = append(side, &labels[k])
+			}
+		}
+		sort.SliceStable(side, func(a, b int) bool { return side[a].y < side[b].y })
+
+		// Push labels down past their upper neighbor, then back up from the bottom edge
+		for k, label := range side {
+			label.y = math.Max(label.y, top)
+			if k > 0 {
+				label.y = math.Max(label.y, side[k-1].y+lineHeight)
+			}
+		}
+		for k := len(side) - 1; k >= 0; k-- {
+			limit := bottom
+			if k < len(side)-1 {
+				limit = side[k+1].y - lineHeight
+			}
+			side[k].y = math.Min(side[k].y, limit)
+		}
+	}
+}
+
+// renderPieLabels draws outside labels with leader lines that bend reach pixels from the
+// pie's center, past the edge of any exploded slice, and run horizontally to the label
+func (c *PieChart) renderPieLabels(svg *strings.Builder, labels []pieLabel, centerX, reach float64) {
+	lineHeight := c.fontSize("label") * 1.3
+	layoutPieLabels(labels, lineHeight, float64(c.Margin.Top)+lineHeight, float64(c.Height-c.Margin.Bottom))
+	for _, label := range labels {
+		elbowX := centerX - reach - 12
+		endX, anchor := elbowX-8, "end"
+		if label.right {
+			elbowX = centerX + reach + 12
+			endX, anchor = elbowX+8, "start"
+		}
+		svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f L%.1f,%.1f L%.1f,%.1f" fill="none" stroke="%s" stroke-width="1"/>`,
+			label.anchorX, label.anchorY, elbowX, label.y-4, endX, label.y-4, c.axisColor()))
+
+		textX := endX - 3
+		if label.right {
+			textX = endX + 3
+		}
+		text := c.truncateText(label.text, "label", label.maxWidth)
+		tooltip := ""
+		if text != label.text {
+			tooltip = "<title>" + escapeText(label.full) + "</title>"
+		}
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="%s" class="chart-label"%s>%s%s</text>`,
+			textX, label.y, anchor, c.textFill(), escapeText(text), tooltip))
+	}
+}
@@ -0,0 +1,504 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// SankeyChart implements a Sankey diagram showing weighted flows between nodes
+type SankeyChart struct {
+	BaseChart
+	Flows       []Flow
+	NodeWidth   int     // Width of each node bar in pixels
+	NodePadding int     // Vertical spacing between nodes in the same column
+	Iterations  int     // Number of relaxation passes used to reduce crossings
+	LinkOpacity float64 // Opacity of the flow bands (0.0-1.0)
+}
+
+// Flow represents a weighted edge from a source node to a target node
+type Flow struct {
+	Source string
+	Target string
+	Value  float64
+}
+
+// sankeyNode holds the computed layout of a single node
+type sankeyNode struct {
+	name        string
+	index       int
+	column      int
+	value       float64
+	x0, x1      float64
+	y0, y1      float64
+	sourceLinks []*sankeyLink // Outgoing links
+	targetLinks []*sankeyLink // Incoming links
+}
+
+// sankeyLink holds the computed layout of a single flow band
+type sankeyLink struct {
+	source, target *sankeyNode
+	index          int // Position in allFlows, which SetPointURL links by
+	value          float64
+	width          float64
+	y0, y1         float64 // Band center at the source and target node
+}
+
+// NewSankeyChart creates a new Sankey diagram with default settings
+func NewSankeyChart() *SankeyChart {
+	chart := &SankeyChart{
+		BaseChart: BaseChart{
+			ChartType:       "sankey",
+			Width:           800,
+			Height:          500,
+			AutoHeight:      false,
+			ShowTitle:       true,
+			ShowLegend:      false,
+			BackgroundColor: "#ffffff",
+			DarkModeSupport: true, // Enable dark mode by default
+		},
+		NodeWidth:   15,
+		NodePadding: 10,
+		Iterations:  32,
+		LinkOpacity: 0.4,
+	}
+
+	chart.Margin.Top = 50
+	chart.Margin.Right = 50
+	chart.Margin.Bottom = 50
+	chart.Margin.Left = 50
+
+	// Default colors
+	chart.Colors = []string{"#3498db", "#e74c3c", "#2ecc71", "#f39c12", "#9b59b6"}
+
+	// Set up default themes
+	chart.EnableDarkModeSupport(true)
+
+	return chart
+}
+
+// SankeyChart methods to implement Chart interface
+
+// SetTitle sets the chart title
+func (c *SankeyChart) SetTitle(title string) Chart {
+	c.Title = title
+	return c
+}
+
+// SetSize sets the chart dimensions in pixels
+func (c *SankeyChart) SetSize(width, height int) Chart {
+	c.Width = width
+	c.Height = height
+	c.AutoHeight = false
+	return c
+}
+
+// SetAutoHeight enables automatic height calculation based on width
+func (c *SankeyChart) SetAutoHeight(auto bool) Chart {
+	c.AutoHeight = auto
+	return c
+}
+
+// SetData sets the flow values, paired with labels of the form "Source > Target"
+func (c *SankeyChart) SetData(data []float64) Chart {
+	c.Data = data
+	return c
+}
+
+// SetLabels sets the flow labels, each of the form "Source > Target"
+func (c *SankeyChart) SetLabels(labels []string) Chart {
+	c.Labels = labels
+	return c
+}
+
+// SetColors sets the node colors as hex values
+func (c *SankeyChart) SetColors(colors []string) Chart {
+	c.Colors = colors
+	return c
+}
+
+// AddSeries is not meaningful for Sankey diagrams
+// This implementation will replace the existing data with the new series
+func (c *SankeyChart) AddSeries(name string, data []float64) Chart {
+	c.Data = data
+	return c
+}
+
+// SetSeriesColors sets the node colors
+// For Sankey diagrams, this is the same as SetColors
+func (c *SankeyChart) SetSeriesColors(colors []string) Chart {
+	c.Colors = colors
+	return c
+}
+
+// SetLegendWidth sets the width of the legend area as a percentage of the chart width
+func (c *SankeyChart) SetLegendWidth(percentage float64) Chart {
+	c.BaseChart.SetLegendWidth(percentage)
+	return c
+}
+
+// SetPalette sets the color palette mode for automatic color assignment
+func (c *SankeyChart) SetPalette(palette string) Chart {
+	c.BaseChart.SetPalette(palette)
+	return c
+}
+
+// AddFlow adds a weighted flow from source to target
+func (c *SankeyChart) AddFlow(source, target string, value float64) *SankeyChart {
+	c.Flows = append(c.Flows, Flow{Source: source, Target: target, Value: value})
+	return c
+}
+
+// SetNodeWidth sets the width of the node bars in pixels
+func (c *SankeyChart) SetNodeWidth(width int) *SankeyChart {
+	c.NodeWidth = width
+	return c
+}
+
+// SetNodePadding sets the vertical spacing between nodes in pixels
+func (c *SankeyChart) SetNodePadding(padding int) *SankeyChart {
+	c.NodePadding = padding
+	return c
+}
+
+// SetIterations sets the number of layout relaxation passes
+func (c *SankeyChart) SetIterations(iterations int) *SankeyChart {
+	c.Iterations = iterations
+	return c
+}
+
+// SetLinkOpacity sets the opacity of the flow bands
+func (c *SankeyChart) SetLinkOpacity(opacity float64) *SankeyChart {
+	c.LinkOpacity = math.Max(0, math.Min(1, opacity))
+	return c
+}
+
+// ParseFlowLabel splits a label of the form "Source > Target" into its two node names
+func ParseFlowLabel(label string) (source, target string, ok bool) {
+	parts := strings.SplitN(label, ">", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	source = strings.TrimSpace(parts[0])
+	target = strings.TrimSpace(parts[1])
+	if source == "" || target == "" {
+		return "", "", false
+	}
+	return source, target, true
+}
+
+// allFlows returns the explicit flows followed by flows described by labels and data
+func (c *SankeyChart) allFlows() []Flow {
+	flows := append([]Flow{}, c.Flows...)
+	for i, label := range c.Labels {
+		if i >= len(c.Data) {
+			break
+		}
+		if source, target, ok := ParseFlowLabel(label); ok {
+			flows = append(flows, Flow{Source: source, Target: target, Value: c.Data[i]})
+		}
+	}
+	return flows
+}
+
+// Render renders the Sankey diagram to an SVG string
+func (c *SankeyChart) Render() string {
+	var svg strings.Builder
+
+	// Apply auto-height if enabled
+	if c.AutoHeight {
+		// For standard charts, use a 16:9 aspect ratio (common screen format)
+		c.Height = c.Width * 9 / 16
+	}
+
+	c.renderHeader(&svg)
+	c.renderTitle(&svg)
+
+	nodes, links := c.layout()
+	if len(nodes) == 0 {
+		svg.WriteString("</svg>")
+		return svg.String()
+	}
+
+	opacity := c.LinkOpacity
+	if opacity <= 0 {
+		opacity = 0.4
+	}
+
+	// Draw flow bands first so the nodes sit on top of them
+	for _, link := range links {
+		x0 := link.source.x1
+		x1 := link.target.x0
+		xm := (x0 + x1) / 2
+		fields := link.fields()
+		linked := c.openLink(&svg, c.markURL(0, link.index), fields, "")
+		svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="none" stroke="%s" stroke-opacity="%.2f" stroke-width="%.1f"%s%s><title>%s</title></path>`,
+			x0, link.y0, xm, link.y0, xm, link.y1, x1, link.y1,
+			c.nodeColor(link.source.index), opacity, math.Max(1, link.width), c.markClass(), c.dataAttributes(fields),
+			escapeText(c.cellTooltip(fields, fmt.Sprintf("%s: %v", fields.label, link.value)))))
+		if linked {
+			svg.WriteString("</a>")
+		}
+	}
+
+	// Draw nodes and their labels
+	lastColumn := 0
+	for _, node := range nodes {
+		if node.column > lastColumn {
+			lastColumn = node.column
+		}
+	}
+	for _, node := range nodes {
+		fields := markFields{label: node.name, value: node.value, percent: Missing()}
+		svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"%s%s><title>%s</title></rect>`,
+			node.x0, node.y0, node.x1-node.x0, math.Max(1, node.y1-node.y0),
+			c.nodeColor(node.index), c.markClass(), c.dataAttributes(fields), escapeText(c.cellTooltip(fields, fmt.Sprintf("%s: %v", node.name, node.value)))))
+
+		// Labels sit to the right of the node, except in the last column
+		labelX, anchor := node.x1+6, "start"
+		if node.column == lastColumn && lastColumn > 0 {
+			labelX, anchor = node.x0-6, "end"
+		}
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="%s" class="chart-label"%s>%s</text>`,
+			labelX, (node.y0+node.y1)/2+4, anchor, c.textFill(), escapeText(node.name)))
+	}
+
+	svg.WriteString("</svg>")
+	return svg.String()
+}
+
+// fields returns what tooltips show about a flow: its source as the series, "Source → Target"
+// as the label, and the {source} and {target} fields
+func (link *sankeyLink) fields() markFields {
+	return markFields{
+		series:  link.source.name,
+		label:   link.source.name + " → " + link.target.name,
+		value:   link.value,
+		percent: Missing(),
+		extra:   [][2]string{{"source", link.source.name}, {"target", link.target.name}},
+	}
+}
+
+// nodeColor returns the color for the node at the given index
+func (c *SankeyChart) nodeColor(index int) string {
+	return c.seriesColor(index)
+}
+
+// layout computes node positions and link bands for the diagram
+func (c *SankeyChart) layout() ([]*sankeyNode, []*sankeyLink) {
+	// Build the graph in order of first appearance
+	var nodes []*sankeyNode
+	var links []*sankeyLink
+	byName := make(map[string]*sankeyNode)
+	nodeFor := func(name string) *sankeyNode {
+		if node, ok := byName[name]; ok {
+			return node
+		}
+		node := &sankeyNode{name: name, index: len(nodes)}
+		byName[name] = node
+		nodes = append(nodes, node)
+		return node
+	}
+
+	for index, flow := range c.allFlows() {
+		if flow.Value <= 0 || flow.Source == flow.Target {
+			continue // Skip empty flows and self-loops
+		}
+		link := &sankeyLink{source: nodeFor(flow.Source), target: nodeFor(flow.Target), index: index, value: flow.Value}
+		link.source.sourceLinks = append(link.source.sourceLinks, link)
+		link.target.targetLinks = append(link.target.targetLinks, link)
+		links = append(links, link)
+	}
+
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	// Node value is the larger of its incoming and outgoing totals
+	for _, node := range nodes {
+		var in, out float64
+		for _, link := range node.targetLinks {
+			in += link.value
+		}
+		for _, link := range node.sourceLinks {
+			out += link.value
+		}
+		node.value = math.Max(in, out)
+	}
+
+	// Assign columns by longest path from the sources, bounded to survive cycles
+	for pass := 0; pass < len(nodes); pass++ {
+		changed := false
+		for _, link := range links {
+			if link.target.column < link.source.column+1 {
+				link.target.column = link.source.column + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	maxColumn := 0
+	for _, node := range nodes {
+		if node.column > maxColumn {
+			maxColumn = node.column
+		}
+	}
+	// Align sinks to the rightmost column
+	for _, node := range nodes {
+		if len(node.sourceLinks) == 0 {
+			node.column = maxColumn
+		}
+	}
+
+	columns := make([][]*sankeyNode, maxColumn+1)
+	for _, node := range nodes {
+		columns[node.column] = append(columns[node.column], node)
+	}
+
+	// Horizontal placement
+	left := float64(c.Margin.Left)
+	right := float64(c.Width - c.Margin.Right)
+	top := float64(c.Margin.Top)
+	bottom := float64(c.Height - c.Margin.Bottom)
+	nodeWidth := float64(c.NodeWidth)
+	if nodeWidth <= 0 {
+		nodeWidth = 15
+	}
+	columnStep := 0.0
+	if maxColumn > 0 {
+		columnStep = (right - left - nodeWidth) / float64(maxColumn)
+	}
+	for _, node := range nodes {
+		node.x0 = left + float64(node.column)*columnStep
+		node.x1 = node.x0 + nodeWidth
+	}
+
+	// Vertical scale shared by all columns so band widths are comparable
+	padding := float64(c.NodePadding)
+	for _, column := range columns {
+		if len(column) > 1 {
+			padding = math.Min(padding, (bottom-top)/float64(len(column)-1)/2)
+		}
+	}
+	ky := math.Inf(1)
+	for _, column := range columns {
+		var total float64
+		for _, node := range column {
+			total += node.value
+		}
+		if total > 0 {
+			ky = math.Min(ky, (bottom-top-float64(len(column)-1)*padding)/total)
+		}
+	}
+	if math.IsInf(ky, 1) || ky < 0 {
+		ky = 0
+	}
+
+	// Initial stacking in order of appearance
+	for _, column := range columns {
+		y := top
+		for _, node := range column {
+			node.y0 = y
+			node.y1 = y + node.value*ky
+			y = node.y1 + padding
+		}
+		resolveSankeyCollisions(column, top, bottom, padding)
+	}
+	for _, link := range links {
+		link.width = link.value * ky
+	}
+
+	// Iterative relaxation: pull nodes towards the weighted center of their neighbors
+	alpha := 1.0
+	for i := 0; i < c.Iterations; i++ {
+		alpha *= 0.99
+		for col := 1; col < len(columns); col++ {
+			for _, node := range columns[col] {
+				relaxSankeyNode(node, node.targetLinks, alpha, func(l *sankeyLink) *sankeyNode { return l.source })
+			}
+			resolveSankeyCollisions(columns[col], top, bottom, padding)
+		}
+		for col := len(columns) - 2; col >= 0; col-- {
+			for _, node := range columns[col] {
+				relaxSankeyNode(node, node.sourceLinks, alpha, func(l *sankeyLink) *sankeyNode { return l.target })
+			}
+			resolveSankeyCollisions(columns[col], top, bottom, padding)
+		}
+	}
+
+	// Order link attachments by the position of the node at the other end
+	for _, node := range nodes {
+		sort.SliceStable(node.sourceLinks, func(i, j int) bool {
+			return node.sourceLinks[i].target.y0 < node.sourceLinks[j].target.y0
+		})
+		sort.SliceStable(node.targetLinks, func(i, j int) bool {
+			return node.targetLinks[i].source.y0 < node.targetLinks[j].source.y0
+		})
+
+		y := node.y0
+		for _, link := range node.sourceLinks {
+			link.y0 = y + link.width/2
+			y += link.width
+		}
+		y = node.y0
+		for _, link := range node.targetLinks {
+			link.y1 = y + link.width/2
+			y += link.width
+		}
+	}
+
+	return nodes, links
+}
+
+// relaxSankeyNode moves a node towards the value-weighted center of the nodes it is linked to
+func relaxSankeyNode(node *sankeyNode, links []*sankeyLink, alpha float64, other func(*sankeyLink) *sankeyNode) {
+	var weighted, total float64
+	for _, link := range links {
+		n := other(link)
+		weighted += (n.y0 + n.y1) / 2 * link.value
+		total += link.value
+	}
+	if total == 0 {
+		return
+	}
+	dy := (weighted/total - (node.y0+node.y1)/2) * alpha
+	node.y0 += dy
+	node.y1 += dy
+}
+
+// resolveSankeyCollisions pushes overlapping nodes in a column apart and back inside the plot area
+func resolveSankeyCollisions(column []*sankeyNode, top, bottom, padding float64) {
+	if len(column) == 0 {
+		return
+	}
+	sort.SliceStable(column, func(i, j int) bool { return column[i].y0 < column[j].y0 })
+
+	// Push down
+	y := top
+	for _, node := range column {
+		if dy := y - node.y0; dy > 0 {
+			node.y0 += dy
+			node.y1 += dy
+		}
+		y = node.y1 + padding
+	}
+
+	// Push back up if the last node overflows the bottom
+	if dy := y - padding - bottom; dy > 0 {
+		last := column[len(column)-1]
+		last.y0 -= dy
+		last.y1 -= dy
+		y = last.y0
+		for i := len(column) - 2; i >= 0; i-- {
+			node := column[i]
+			if dy := node.y1 + padding - y; dy > 0 {
+				node.y0 -= dy
+				node.y1 -= dy
+			}
+			y = node.y0
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SparklineChart implements a small axis-free trend line or bar strip for inline use
+type SparklineChart struct {
+	BaseChart
+	Mode          string  // "line" or "bar"
+	LineWidth     float64 // Stroke width of the line in pixels
+	Fill          bool    // Fill the area under the line
+	ShowMin       bool    // Highlight the minimum value
+	ShowMax       bool    // Highlight the maximum value
+	ShowLast      bool    // Highlight the last value
+	MinColor      string  // Color used to highlight the minimum value
+	MaxColor      string  // Color used to highlight the maximum value
+	LastColor     string  // Color used to highlight the last value
+	NegativeColor string  // Color for negative bars in bar mode
+	ShowBand      bool    // Draw a shaded reference band
+	BandMin       float64 // Lower bound of the reference band
+	BandMax       float64 // Upper bound of the reference band
+	BandColor     string  // Fill color of the reference band
+}
+
+// NewSparklineChart creates a new sparkline with default settings
+func NewSparklineChart() *SparklineChart {
+	chart := &SparklineChart{
+		BaseChart: BaseChart{
+			ChartType:       "sparkline",
+			Width:           100,
+			Height:          24,
+			AutoHeight:      false,
+			ShowTitle:       false,
+			ShowLegend:      false,
+			BackgroundColor: "none",
+			DarkModeSupport: false, // Sparklines are transparent and inherit the surrounding page
+		},
+		Mode:          "line",
+		LineWidth:     1.5,
+		MinColor:      "#e74c3c",
+		MaxColor:      "#2ecc71",
+		LastColor:     "#3498db",
+		NegativeColor: "#e74c3c",
+		BandColor:     "#999999",
+	}
+
+	// Default colors
+	chart.Colors = []string{"#3498db", "#e74c3c", "#2ecc71", "#f39c12", "#9b59b6"}
+
+	return chart
+}
+
+// SparklineChart methods to implement Chart interface
+
+// SetTitle sets the sparkline title, rendered as a hover tooltip
+func (c *SparklineChart) SetTitle(title string) Chart {
+	c.Title = title
+	return c
+}
+
+// SetSize sets the sparkline dimensions in pixels
+func (c *SparklineChart) SetSize(width, height int) Chart {
+	c.Width = width
+	c.Height = height
+	c.AutoHeight = false
+	return c
+}
+
+// SetAutoHeight enables automatic height calculation based on width
+func (c *SparklineChart) SetAutoHeight(auto bool) Chart {
+	c.AutoHeight = auto
+	return c
+}
+
+// SetData sets the sparkline data values
+func (c *SparklineChart) SetData(data []float64) Chart {
+	c.Data = data
+	return c
+}
+
+// SetLabels sets the labels, used only for tooltips
+func (c *SparklineChart) SetLabels(labels []string) Chart {
+	c.Labels = labels
+	return c
+}
+
+// SetColors sets the color palette as hex values, the first color is used for the line or bars
+func (c *SparklineChart) SetColors(colors []string) Chart {
+	c.Colors = colors
+	return c
+}
+
+// AddSeries adds a data series to the sparkline
+// Sparklines show a single series, so this replaces the existing data
+func (c *SparklineChart) AddSeries(name string, data []float64) Chart {
+	c.Data = data
+	return c
+}
+
+// SetSeriesColors sets the colors for the data series
+// For sparklines, this is the same as SetColors
+func (c *SparklineChart) SetSeriesColors(colors []string) Chart {
+	c.Colors = colors
+	return c
+}
+
+// SetLegendWidth is accepted for interface compatibility, sparklines have no legend
+func (c *SparklineChart) SetLegendWidth(percentage float64) Chart {
+	c.BaseChart.SetLegendWidth(percentage)
+	return c
+}
+
+// SetPalette sets the color palette mode for automatic color assignment
+func (c *SparklineChart) SetPalette(palette string) Chart {
+	c.BaseChart.SetPalette(palette)
+	return c
+}
+
+// SetMode sets the sparkline style, either "line" or "bar"
+func (c *SparklineChart) SetMode(mode string) *SparklineChart {
+	mode = strings.ToLower(mode)
+	if mode == "line" || mode == "bar" {
+		c.Mode = mode
+	}
+	return c
+}
+
+// SetLineWidth sets the stroke width of the line
+func (c *SparklineChart) SetLineWidth(width float64) *SparklineChart {
+	c.LineWidth = width
+	return c
+}
+
+// SetFill fills the area under the line
+func (c *SparklineChart) SetFill(fill bool) *SparklineChart {
+	c.Fill = fill
+	return c
+}
+
+// SetHighlights selects which points are emphasized
+func (c *SparklineChart) SetHighlights(showMin, showMax, showLast bool) *SparklineChart {
+	c.ShowMin = showMin
+	c.ShowMax = showMax
+	c.ShowLast = showLast
+	return c
+}
+
+// SetReferenceBand draws a shaded band between two values, such as a normal range
+func (c *SparklineChart) SetReferenceBand(min, max float64) *SparklineChart {
+	if min > max {
+		min, max = max, min
+	}
+	c.ShowBand = true
+	c.BandMin = min
+	c.BandMax = max
+	return c
+}
+
+// Render renders the sparkline to an SVG string
+func (c *SparklineChart) Render() string {
+	var svg strings.Builder
+
+	// Apply auto-height if enabled
+	if c.AutoHeight {
+		// Sparklines are wide and short, like a word in a sentence
+		c.Height = c.Width / 4
+	}
+
+	// Fixed pixel size so the chart flows inline with text, hover highlighting needs a class to scope its style
+	class, hover := "", ""
+	if c.HoverHighlight {
+		scope, _ := c.typographyScope()
+		class, hover = fmt.Sprintf(` class="%s"`, scope), c.hoverStyle(scope)
+	}
+	svg.WriteString(fmt.Sprintf(`<svg width="%d" height="%d" viewBox="0 0 %d %d" style="vertical-align: middle"%s xmlns="http://www.w3.org/2000/svg">`,
+		c.Width, c.Height, c.Width, c.Height, class))
+	if c.Title != "" {
+		svg.WriteString(fmt.Sprintf(`<title>%s</title>`, escapeText(c.Title)))
+	}
+	svg.WriteString(hover)
+	if c.BackgroundColor != "" && c.BackgroundColor != "none" {
+		svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, c.Width, c.Height, c.BackgroundColor))
+	}
+
+	if len(c.Data) == 0 {
+		svg.WriteString("</svg>")
+		return svg.String()
+	}
+
+	// Find the value range and the last value, skipping missing values,
+	// then include the baseline for bars and the reference band
+	minIndex, maxIndex, lastIndex := -1, -1, -1
+	for i, v := range c.Data {
+		if IsMissing(v) {
+			continue
+		}
+		if minIndex < 0 || v < c.Data[minIndex] {
+			minIndex = i
+		}
+		if maxIndex < 0 || v > c.Data[maxIndex] {
+			maxIndex = i
+		}
+		lastIndex = i
+	}
+	if lastIndex < 0 {
+		svg.WriteString("</svg>")
+		return svg.String()
+	}
+	minValue, maxValue := c.Data[minIndex], c.Data[maxIndex]
+	lo, hi := minValue, maxValue
+	if c.Mode == "bar" {
+		lo, hi = math.Min(lo, 0), math.Max(hi, 0)
+	}
+	if c.ShowBand {
+		lo, hi = math.Min(lo, c.BandMin), math.Max(hi, c.BandMax)
+	}
+
+	// Leave room for highlight markers at the edges
+	radius := math.Max(1.5, math.Min(3, float64(c.Height)/8))
+	pad := radius + 0.5
+	plotWidth := float64(c.Width) - 2*pad
+	plotHeight := float64(c.Height) - 2*pad
+	yFor := func(v float64) float64 {
+		if hi == lo {
+			return pad + plotHeight/2
+		}
+		return pad + (hi-v)/(hi-lo)*plotHeight
+	}
+
+	color := c.seriesColor(0)
+
+	// Reference band behind the data
+	if c.ShowBand {
+		y0, y1 := yFor(c.BandMax), yFor(c.BandMin)
+		svg.WriteString(fmt.Sprintf(`<rect x="0" y="%.1f" width="%d" height="%.1f" fill="%s" fill-opacity="0.2"/>`,
+			y0, c.Width, math.Max(0.5, y1-y0), c.BandColor))
+	}
+
+	// Highlight colors by index, later entries win
+	highlight := make(map[int]string)
+	if c.ShowMin {
+		highlight[minIndex] = c.MinColor
+	}
+	if c.ShowMax {
+		highlight[maxIndex] = c.MaxColor
+	}
+	if c.ShowLast {
+		highlight[lastIndex] = c.LastColor
+	}
+
+	if c.Mode == "bar" {
+		slot := plotWidth / float64(len(c.Data))
+		gap := 0.0
+		if slot > 3 {
+			gap = 1
+		}
+		baseline := yFor(0)
+		for i, v := range c.Data {
+			if IsMissing(v) {
+				continue
+			}
+			barColor := color
+			if v < 0 {
+				barColor = c.NegativeColor
+			}
+			if hc, ok := highlight[i]; ok {
+				barColor = hc
+			}
+			y := math.Min(yFor(v), baseline)
+			attrs := c.beginMark(&svg, 0, i)
+			svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"%s>%s</rect>`,
+				pad+float64(i)*slot+gap/2, y, math.Max(0.5, slot-gap), math.Max(0.5, math.Abs(yFor(v)-baseline)),
+				barColor, attrs, c.sparkTitle(i)))
+			c.endMark(&svg, 0, i)
+		}
+		svg.WriteString("</svg>")
+		return svg.String()
+	}
+
+	// Line mode, broken at missing values
+	points := make([][2]float64, len(c.Data))
+	for i, v := range c.Data {
+		x := pad + plotWidth/2
+		if len(c.Data) > 1 {
+			x = pad + float64(i)*plotWidth/float64(len(c.Data)-1)
+		}
+		points[i] = [2]float64{x, yFor(v)}
+	}
+
+	_, segments := gapSegments(c.Data, GapBreak)
+	for _, segment := range segments {
+		var path strings.Builder
+		for k, i := range segment {
+			if k == 0 {
+				path.WriteString(fmt.Sprintf("M%.1f,%.1f", points[i][0], points[i][1]))
+			} else {
+				path.WriteString(fmt.Sprintf(" L%.1f,%.1f", points[i][0], points[i][1]))
+			}
+		}
+
+		first, last := points[segment[0]], points[segment[len(segment)-1]]
+		if c.Fill {
+			svg.WriteString(fmt.Sprintf(`<path d="%s L%.1f,%.1f L%.1f,%.1f Z" fill="%s" fill-opacity="0.2"/>`,
+				path.String(), last[0], pad+plotHeight, first[0], pad+plotHeight, color))
+		}
+		svg.WriteString(fmt.Sprintf(`<path d="%s" fill="none" stroke="%s" stroke-width="%.1f" stroke-linejoin="round" stroke-linecap="round"/>`,
+			path.String(), color, c.LineWidth))
+	}
+
+	for i, p := range points {
+		if hc, ok := highlight[i]; ok {
+			attrs := c.beginMark(&svg, 0, i)
+			svg.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"%s>%s</circle>`,
+				p[0], p[1], radius, hc, attrs, c.sparkTitle(i)))
+			c.endMark(&svg, 0, i)
+		}
+	}
+
+	svg.WriteString("</svg>")
+	return svg.String()
+}
+
+// sparkTitle returns the built-in tooltip of the value at index i as a title element, or
+// nothing when a tooltip template is set, since beginMark writes that one
+func (c *SparklineChart) sparkTitle(i int) string {
+	if c.Tooltip != "" {
+		return ""
+	}
+	text := fmt.Sprintf("%v", c.Data[i])
+	if i < len(c.Labels) {
+		text = fmt.Sprintf("%s: %v", c.Labels[i], c.Data[i])
+	}
+	return "<title>" + escapeText(text) + "</title>"
+}
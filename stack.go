@@ -0,0 +1,112 @@
+package gosvgchart
+
+// Stack modes for bar charts with multiple series
+const (
+	StackGrouped        = "grouped"         // One bar per series side by side
+	StackStacked        = "stacked"         // All series stacked into one bar
+	StackPercent        = "percent"         // All series stacked into one bar that sums to 100%
+	StackGroupedStacked = "grouped-stacked" // One stack per stack group, side by side
+)
+
+// SetStackMode sets how the series of a bar chart combine: "grouped", "stacked",
+// "percent" or "grouped-stacked"
+func (c *BarChart) SetStackMode(mode string) *BarChart {
+	c.StackMode = mode
+	return c
+}
+
+// SetStackGroup assigns series to a named stack group for the grouped-stacked mode,
+// such as the products of one year; series are matched by name when the chart is
+// rendered, so they may be added before or after
+func (c *BarChart) SetStackGroup(group string, series ...string) *BarChart {
+	if c.StackGroups == nil {
+		c.StackGroups = make(map[string]string)
+	}
+	for _, name := range series {
+		c.StackGroups[name] = group
+	}
+	return c
+}
+
+// stackMode returns the stack mode, falling back to the Stacked flag
+func (c *BarChart) stackMode() string {
+	if c.StackMode != "" {
+		return c.StackMode
+	}
+	if c.Stacked {
+		return StackStacked
+	}
+	return StackGrouped
+}
+
+// barStacks returns the stack group names and the series indexes of each stack, left to right
+// Stacks are ordered by their first series; a series without a group is a stack of its own
+func (c *BarChart) barStacks() ([]string, [][]int) {
+	var names []string
+	var stacks [][]int
+	switch c.stackMode() {
+	case StackStacked, StackPercent:
+		names = []string{""}
+		stacks = [][]int{make([]int, len(c.Series))}
+		for i := range c.Series {
+			stacks[0][i] = i
+		}
+	case StackGroupedStacked:
+		index := make(map[string]int)
+		for i, series := range c.Series {
+			group := c.StackGroups[series.Name]
+			if k, ok := index[group]; ok && group != "" {
+				stacks[k] = append(stacks[k], i)
+				continue
+			}
+			index[group] = len(stacks)
+			names = append(names, group)
+			stacks = append(stacks, []int{i})
+		}
+	default:
+		for i := range c.Series {
+			names = append(names, "")
+			stacks = append(stacks, []int{i})
+		}
+	}
+	return names, stacks
+}
+
+// stackTotal returns the sum of the positive values of a stack at category i
+func (c *BarChart) stackTotal(stack []int, i int) float64 {
+	var total float64
+	for _, s := range stack {
+		if i < len(c.Series[s].Data) && c.Series[s].Data[i] > 0 {
+			total += c.Series[s].Data[i]
+		}
+	}
+	return total
+}
+
+// segmentValue returns the height of a series' segment at category i as drawn: the value,
+// or its share of the stack in percent mode; missing and non-positive values are zero
+func (c *BarChart) segmentValue(stack []int, series, i int) float64 {
+	if i >= len(c.Series[series].Data) || !(c.Series[series].Data[i] > 0) {
+		return 0
+	}
+	value := c.Series[series].Data[i]
+	if c.stackMode() == StackPercent {
+		return value / c.stackTotal(stack, i) * 100
+	}
+	return value
+}
+
+// maxStackValue returns the height of the tallest stack
+func (c *BarChart) maxStackValue() float64 {
+	if c.stackMode() == StackPercent {
+		return 100
+	}
+	var maxValue float64
+	_, stacks := c.barStacks()
+	for _, stack := range stacks {
+		for i := 0; i < c.categoryCount(); i++ {
+			maxValue = max(maxValue, c.stackTotal(stack, i))
+		}
+	}
+	return maxValue
+}
@@ -0,0 +1,132 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Marker shapes for the points of line series
+const (
+	MarkerCircle   = "circle"
+	MarkerSquare   = "square"
+	MarkerTriangle = "triangle"
+	MarkerDiamond  = "diamond"
+	MarkerCross    = "cross"
+	MarkerNone     = "none" // No markers for the series
+)
+
+// Named dash patterns for line series
+const (
+	DashSolid   = "solid"
+	DashDashed  = "8,4"
+	DashDotted  = "2,3"
+	DashDashDot = "8,3,2,3"
+)
+
+// SeriesStyle sets how a line series is drawn, so series stay apart without relying on color
+// Empty fields cycle through defaults by series index
+type SeriesStyle struct {
+	Dash       string  // SVG stroke-dasharray such as "6,4", or DashSolid
+	Width      float64 // Stroke width, the theme line width when zero
+	Opacity    float64 // Opacity of the line and its markers from 0 to 1, opaque when zero
+	Marker     string  // One of the Marker* shapes
+	MarkerSize float64 // Marker radius, 5 when zero
+}
+
+// Styles the series cycle through when they set none
+var (
+	defaultDashes  = []string{DashSolid, DashDashed, DashDotted, DashDashDot, "12,4"}
+	defaultMarkers = []string{MarkerCircle, MarkerSquare, MarkerTriangle, MarkerDiamond, MarkerCross}
+)
+
+// SetSeriesStyle sets the dash pattern, stroke width, opacity and markers of a line series
+// An empty series name sets the style of the single series data
+func (chart *BaseChart) SetSeriesStyle(series string, style SeriesStyle) *BaseChart {
+	if series == "" && len(chart.Series) == 0 {
+		chart.DataStyle = style
+		return chart
+	}
+	for i := range chart.Series {
+		if chart.Series[i].Name == series {
+			chart.Series[i].Style = style
+		}
+	}
+	return chart
+}
+
+// seriesStyle returns the style of a series with the defaults for its index filled in
+func (chart *BaseChart) seriesStyle(index int) SeriesStyle {
+	style := chart.DataStyle
+	if index < len(chart.Series) {
+		style = chart.Series[index].Style
+	}
+	if style.Dash == "" {
+		style.Dash = defaultDashes[index%len(defaultDashes)]
+	}
+	if style.Width <= 0 {
+		style.Width = chart.lineWidth()
+	}
+	if style.Opacity <= 0 || style.Opacity > 1 {
+		style.Opacity = 1
+	}
+	if style.Marker == "" {
+		style.Marker = defaultMarkers[index%len(defaultMarkers)]
+	}
+	if style.MarkerSize <= 0 {
+		style.MarkerSize = 5
+	}
+	return style
+}
+
+// strokeAttributes returns the dash and opacity attributes of a series line
+func (style SeriesStyle) strokeAttributes() string {
+	var attrs strings.Builder
+	if style.Dash != DashSolid {
+		attrs.WriteString(fmt.Sprintf(` stroke-dasharray="%s"`, style.Dash))
+	}
+	if style.Opacity < 1 {
+		attrs.WriteString(fmt.Sprintf(` opacity="%g"`, style.Opacity))
+	}
+	return attrs.String()
+}
+
+// renderMarker draws a point marker of the series style centered on x, y, with extra
+// attributes such as data attributes added to its element
+func renderMarker(svg *strings.Builder, style SeriesStyle, x, y float64, color, attrs string) {
+	r := style.MarkerSize
+	if style.Opacity < 1 {
+		attrs = fmt.Sprintf(` opacity="%g"`, style.Opacity) + attrs
+	}
+	switch style.Marker {
+	case MarkerNone:
+	case MarkerSquare:
+		// Slightly smaller than the circle so both look the same size
+		s := r * 0.9
+		svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"%s/>`,
+			x-s, y-s, 2*s, 2*s, color, attrs))
+	case MarkerTriangle:
+		s := r * 1.2
+		svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f L%.1f,%.1f L%.1f,%.1f Z" fill="%s"%s/>`,
+			x, y-s, x+s, y+s*0.8, x-s, y+s*0.8, color, attrs))
+	case MarkerDiamond:
+		s := r * 1.2
+		svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f L%.1f,%.1f L%.1f,%.1f L%.1f,%.1f Z" fill="%s"%s/>`,
+			x, y-s, x+s, y, x, y+s, x-s, y, color, attrs))
+	case MarkerCross:
+		svg.WriteString(fmt.Sprintf(`<path d="M%.1f,%.1f L%.1f,%.1f M%.1f,%.1f L%.1f,%.1f" stroke="%s" stroke-width="%g" fill="none"%s/>`,
+			x-r, y-r, x+r, y+r, x-r, y+r, x+r, y-r, color, r*0.5, attrs))
+	default:
+		svg.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="%g" fill="%s"%s/>`, x, y, r, color, attrs))
+	}
+}
+
+// renderLineLegendKey draws the legend key of a line series: a short line in the
+// series dash pattern with its marker in the middle
+func (c *LineChart) renderLineLegendKey(svg *strings.Builder, x, y int, style SeriesStyle, color string) {
+	svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%g"%s/>`,
+		x, y+8, x+15, y+8, color, style.Width, style.strokeAttributes()))
+	if c.ShowPoints {
+		style.MarkerSize = min(style.MarkerSize, 4)
+		renderMarker(svg, style, float64(x)+7.5, float64(y)+8, color, "")
+	}
+}
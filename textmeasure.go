@@ -0,0 +1,177 @@
+package gosvgchart
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FontMetrics holds the advance widths of the printable ASCII characters for one font
+// Widths are in font units, so a character is width/UnitsPerEm of the font size wide
+type FontMetrics struct {
+	Name       string
+	UnitsPerEm float64
+	Widths     [95]float64 // Characters from space (32) to tilde (126)
+	Fallback   float64     // Width used for other characters
+}
+
+// arialMetrics are the advance widths of Arial, which match Helvetica
+var arialMetrics = FontMetrics{
+	Name:       "arial",
+	UnitsPerEm: 1000,
+	Widths: [95]float64{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0-9
+		278, 278, 584, 584, 584, 556, 1015, // : to @
+		667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A-M
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N-Z
+		278, 278, 278, 469, 556, 333, // [ to `
+		556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a-m
+		556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n-z
+		334, 260, 334, 584, // { to ~
+	},
+	Fallback: 556,
+}
+
+// dejaVuSansMetrics are the advance widths of DejaVu Sans, a common default on Linux (and close to Verdana)
+var dejaVuSansMetrics = FontMetrics{
+	Name:       "dejavu sans",
+	UnitsPerEm: 2048,
+	Widths: [95]float64{
+		651, 821, 942, 1716, 1303, 1946, 1597, 563, 799, 799, 1024, 1716, 651, 739, 651, 690, // space to /
+		1303, 1303, 1303, 1303, 1303, 1303, 1303, 1303, 1303, 1303, // 0-9
+		690, 690, 1716, 1716, 1716, 1087, 2048, // : to @
+		1401, 1405, 1430, 1577, 1294, 1178, 1587, 1540, 604, 604, 1343, 1141, 1767, // A-M
+		1532, 1612, 1235, 1612, 1423, 1300, 1251, 1499, 1401, 2025, 1403, 1251, 1403, // N-Z
+		799, 690, 799, 1716, 1024, 1024, // [ to `
+		1255, 1300, 1126, 1300, 1260, 721, 1300, 1298, 569, 569, 1186, 569, 1995, // a-m
+		1298, 1253, 1300, 1300, 842, 1067, 803, 1298, 1212, 1675, 1212, 1212, 1075, // n-z
+		1303, 690, 1303, 1716, // { to ~
+	},
+	Fallback: 1260,
+}
+
+// monospaceMetrics cover Courier, Menlo, Consolas and other fixed width fonts
+var monospaceMetrics = func() FontMetrics {
+	m := FontMetrics{Name: "monospace", UnitsPerEm: 1000, Fallback: 600}
+	for i := range m.Widths {
+		m.Widths[i] = 600
+	}
+	return m
+}()
+
+// metricsForFamily picks the metrics for the first recognized font in a CSS font stack
+// Unknown stacks are measured as Arial
+func metricsForFamily(family string) FontMetrics {
+	for _, name := range strings.Split(strings.ToLower(family), ",") {
+		name = strings.Trim(strings.TrimSpace(name), `"'`)
+		switch {
+		case strings.Contains(name, "mono") || strings.Contains(name, "courier") || strings.Contains(name, "consolas") || strings.Contains(name, "menlo"):
+			return monospaceMetrics
+		case strings.Contains(name, "dejavu") || strings.Contains(name, "verdana") || strings.Contains(name, "bitstream vera"):
+			return dejaVuSansMetrics
+		case strings.Contains(name, "arial") || strings.Contains(name, "helvetica") || name == "sans-serif":
+			return arialMetrics
+		}
+	}
+	return arialMetrics
+}
+
+// MeasureText estimates the rendered width in pixels of text in the given CSS font stack and size
+// Bold text is about 7% wider than regular text
+func MeasureText(text, family string, size float64, bold bool) float64 {
+	metrics := metricsForFamily(family)
+	var units float64
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126:
+			units += metrics.Widths[r-32]
+		case isWideRune(r):
+			units += metrics.UnitsPerEm // CJK and fullwidth characters are one em wide
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks don't advance
+		default:
+			units += metrics.Fallback
+		}
+	}
+	width := units / metrics.UnitsPerEm * size
+	if bold {
+		width *= 1.07
+	}
+	return width
+}
+
+// TruncateText shortens text with an ellipsis so it fits within maxWidth pixels
+// Text that already fits is returned unchanged
+func TruncateText(text, family string, size, maxWidth float64, bold bool) string {
+	if MeasureText(text, family, size, bold) <= maxWidth {
+		return text
+	}
+	const ellipsis = "…"
+	runes := []rune(text)
+	for n := len(runes) - 1; n > 0; n-- {
+		candidate := strings.TrimRight(string(runes[:n]), " ") + ellipsis
+		if MeasureText(candidate, family, size, bold) <= maxWidth {
+			return candidate
+		}
+	}
+	return ellipsis
+}
+
+// isWideRune reports whether r is an East Asian wide or fullwidth character
+func isWideRune(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115F) || (r >= 0x2E80 && r <= 0xA4CF) || (r >= 0xAC00 && r <= 0xD7A3) ||
+		(r >= 0xF900 && r <= 0xFAFF) || (r >= 0xFE30 && r <= 0xFE4F) || (r >= 0xFF00 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6)
+}
+
+// fontFamily returns the CSS font stack the chart's text is rendered with
+func (chart *BaseChart) fontFamily() string {
+	if chart.Typography.FontFamily != "" {
+		return chart.Typography.FontFamily
+	}
+	return "Arial, sans-serif"
+}
+
+// fontSize returns the font size in pixels for a text role: "title", "label", "tick" or "legend"
+func (chart *BaseChart) fontSize(role string) float64 {
+	t := chart.Typography
+	size, fallback := 0, 12
+	switch role {
+	case "title":
+		size, fallback = t.TitleSize, 20
+	case "label":
+		size = t.LabelSize
+	case "tick":
+		size, fallback = t.TickSize, 10
+	case "legend":
+		size = t.LegendSize
+	}
+	if size <= 0 {
+		size = fallback
+	}
+	return float64(size)
+}
+
+// fontBold reports whether a text role is rendered bold
+func (chart *BaseChart) fontBold(role string) bool {
+	weight := map[string]string{
+		"title":  valueOr(chart.Typography.TitleWeight, "bold"),
+		"label":  chart.Typography.LabelWeight,
+		"tick":   chart.Typography.TickWeight,
+		"legend": chart.Typography.LegendWeight,
+	}[role]
+	if n, err := strconv.Atoi(weight); err == nil {
+		return n >= 600
+	}
+	return weight == "bold" || weight == "bolder"
+}
+
+// textWidth estimates the width in pixels of text drawn in the given role
+func (chart *BaseChart) textWidth(text, role string) float64 {
+	return MeasureText(text, chart.fontFamily(), chart.fontSize(role), chart.fontBold(role))
+}
+
+// truncateText shortens text drawn in the given role to fit within maxWidth pixels
+func (chart *BaseChart) truncateText(text, role string, maxWidth float64) string {
+	return TruncateText(text, chart.fontFamily(), chart.fontSize(role), maxWidth, chart.fontBold(role))
+}
@@ -0,0 +1,376 @@
+package gosvgchart
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Theme bundles the visual settings of a chart so they can be shared and stored as JSON
+// Zero values leave the chart's own defaults in place
+type Theme struct {
+	Name       string       `json:"name"`
+	Typography Typography   `json:"typography"`
+	Light      ThemeColors  `json:"light"`             // Colors in light mode, also used when dark mode is off
+	Dark       ThemeColors  `json:"dark"`              // Colors when the viewer prefers a dark color scheme
+	NoDarkMode bool         `json:"noDarkMode"`        // Always use the light colors, e.g. for print
+	Spacing    ThemeSpacing `json:"spacing"`           // Margins and legend width
+	Strokes    StrokeWidths `json:"strokes"`           // Line thickness
+	Palette    string       `json:"palette,omitempty"` // Named palette for series and slices, see PaletteNames
+	Colors     []string     `json:"colors,omitempty"`  // Custom series colors, used when Palette is empty
+	Patterns   bool         `json:"patterns"`          // Fill bars, slices and areas with patterns
+}
+
+// Typography describes the fonts used for chart text
+// Text is drawn in four roles: the title, labels (axis labels and values), ticks (small labels) and legends
+type Typography struct {
+	FontFamily   string        `json:"fontFamily,omitempty"`   // CSS font stack, "Arial, sans-serif" by default
+	TitleSize    int           `json:"titleSize,omitempty"`    // 20 by default
+	LabelSize    int           `json:"labelSize,omitempty"`    // 12 by default
+	TickSize     int           `json:"tickSize,omitempty"`     // 10 by default
+	LegendSize   int           `json:"legendSize,omitempty"`   // 12 by default
+	TitleWeight  string        `json:"titleWeight,omitempty"`  // "bold" by default
+	LabelWeight  string        `json:"labelWeight,omitempty"`  // "normal" by default
+	TickWeight   string        `json:"tickWeight,omitempty"`   // "normal" by default
+	LegendWeight string        `json:"legendWeight,omitempty"` // "normal" by default
+	FontFace     *EmbeddedFont `json:"fontFace,omitempty"`     // Optional font embedded in the SVG
+}
+
+// EmbeddedFont is a WOFF2 font embedded with @font-face, for output that does not depend on installed fonts
+// The font data is stored base64 encoded in JSON themes
+type EmbeddedFont struct {
+	Family string `json:"family"`
+	WOFF2  []byte `json:"woff2"`
+}
+
+// ThemeColors holds the colors for one color scheme
+type ThemeColors struct {
+	Background string `json:"background,omitempty"`
+	Text       string `json:"text,omitempty"`
+	Axis       string `json:"axis,omitempty"`
+	Grid       string `json:"grid,omitempty"`
+}
+
+// ThemeSpacing holds the chart margins in pixels and the legend width as a fraction of the chart width
+type ThemeSpacing struct {
+	MarginTop    int     `json:"marginTop,omitempty"`
+	MarginRight  int     `json:"marginRight,omitempty"`
+	MarginBottom int     `json:"marginBottom,omitempty"`
+	MarginLeft   int     `json:"marginLeft,omitempty"`
+	LegendWidth  float64 `json:"legendWidth,omitempty"`
+}
+
+// StrokeWidths sets line thickness in pixels, zero values use the chart defaults
+type StrokeWidths struct {
+	Line float64 `json:"line,omitempty"` // Data lines, 3 by default
+	Axis float64 `json:"axis,omitempty"` // Axis lines, 2 by default
+	Grid float64 `json:"grid,omitempty"` // Grid and tick lines, 1 by default
+}
+
+// themesMu guards builtinThemes, since RegisterTheme may run while other charts render
+var themesMu sync.RWMutex
+
+// builtinThemes contains the themes available by name
+var builtinThemes = map[string]Theme{
+	"default": {
+		Name:  "default",
+		Light: ThemeColors{Background: "#ffffff", Text: "#000000", Axis: "#666666", Grid: "#dddddd"},
+		Dark:  ThemeColors{Background: "#121212", Text: "#ffffff", Axis: "#aaaaaa", Grid: "#333333"},
+	},
+	"minimal": {
+		Name:       "minimal",
+		Typography: Typography{FontFamily: "Helvetica, Arial, sans-serif", TitleSize: 16, LabelSize: 11, TickSize: 9, LegendSize: 11, TitleWeight: "600"},
+		Light:      ThemeColors{Background: "#ffffff", Text: "#444444", Axis: "#bbbbbb", Grid: "#eeeeee"},
+		Dark:       ThemeColors{Background: "#1a1a1a", Text: "#cccccc", Axis: "#555555", Grid: "#2a2a2a"},
+		Spacing:    ThemeSpacing{MarginTop: 40, MarginRight: 30, MarginBottom: 40, MarginLeft: 40},
+		Strokes:    StrokeWidths{Line: 2, Axis: 1, Grid: 0.5},
+		Palette:    "tableau10",
+	},
+	"high-contrast": {
+		Name:       "high-contrast",
+		Typography: Typography{FontFamily: "Verdana, Arial, sans-serif", TitleSize: 22, LabelSize: 14, TickSize: 12, LegendSize: 14, LabelWeight: "bold"},
+		Light:      ThemeColors{Background: "#ffffff", Text: "#000000", Axis: "#000000", Grid: "#767676"},
+		Dark:       ThemeColors{Background: "#000000", Text: "#ffffff", Axis: "#ffffff", Grid: "#8a8a8a"},
+		Strokes:    StrokeWidths{Line: 4, Axis: 3, Grid: 1},
+		Palette:    "okabe-ito",
+		Patterns:   true,
+	},
+	"print": {
+		Name:       "print",
+		Typography: Typography{FontFamily: "Georgia, 'Times New Roman', serif"},
+		Light:      ThemeColors{Background: "#ffffff", Text: "#000000", Axis: "#000000", Grid: "#cccccc"},
+		NoDarkMode: true,
+		Strokes:    StrokeWidths{Line: 2, Axis: 1, Grid: 0.5},
+		Colors:     []string{"#000000", "#555555", "#888888", "#bbbbbb", "#333333", "#aaaaaa"},
+		Patterns:   true,
+	},
+	"solarized": {
+		Name:    "solarized",
+		Light:   ThemeColors{Background: "#fdf6e3", Text: "#586e75", Axis: "#93a1a1", Grid: "#eee8d5"},
+		Dark:    ThemeColors{Background: "#002b36", Text: "#93a1a1", Axis: "#586e75", Grid: "#073642"},
+		Colors:  []string{"#268bd2", "#dc322f", "#859900", "#b58900", "#6c71c4", "#2aa198", "#d33682", "#cb4b16"},
+		Strokes: StrokeWidths{Line: 2.5},
+	},
+}
+
+// NamedTheme returns a built-in or registered theme by name (case insensitive)
+func NamedTheme(name string) (Theme, bool) {
+	themesMu.RLock()
+	theme, ok := builtinThemes[strings.ToLower(strings.TrimSpace(name))]
+	themesMu.RUnlock()
+	if ok {
+		theme.Colors = append([]string(nil), theme.Colors...)
+	}
+	return theme, ok
+}
+
+// ThemeNames returns the names of the available themes in alphabetical order
+func ThemeNames() []string {
+	themesMu.RLock()
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	themesMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// RegisterTheme makes a theme available by its name, for NamedTheme and the markdown `theme` key
+// Registering a built-in name replaces it; it is safe to call while charts render
+func RegisterTheme(theme Theme) error {
+	name := strings.ToLower(strings.TrimSpace(theme.Name))
+	if name == "" {
+		return fmt.Errorf("theme name cannot be empty")
+	}
+	theme.Name = name
+	theme.Colors = append([]string(nil), theme.Colors...)
+	themesMu.Lock()
+	builtinThemes[name] = theme
+	themesMu.Unlock()
+	return nil
+}
+
+// LoadTheme reads a theme from JSON
+// Fields missing from the JSON keep the values of the default theme
+func LoadTheme(r io.Reader) (Theme, error) {
+	theme, _ := NamedTheme("default")
+	if err := json.NewDecoder(r).Decode(&theme); err != nil {
+		return Theme{}, fmt.Errorf("invalid theme: %w", err)
+	}
+	return theme, nil
+}
+
+// Save writes the theme as indented JSON
+func (t Theme) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
+}
+
+// ApplyTheme applies a theme to any chart created by this package
+func ApplyTheme(chart Chart, theme Theme) Chart {
+	if themed, ok := chart.(interface{ ApplyTheme(Theme) *BaseChart }); ok {
+		themed.ApplyTheme(theme)
+	}
+	return chart
+}
+
+// ApplyTheme copies the theme's colors, typography, spacing, strokes and palette to the chart
+func (chart *BaseChart) ApplyTheme(theme Theme) *BaseChart {
+	chart.Typography = theme.Typography
+	chart.Strokes = theme.Strokes
+
+	// Sparklines stay transparent so they blend into the surrounding text
+	if chart.ChartType != "sparkline" {
+		if theme.Light.Background != "" {
+			chart.BackgroundColor = theme.Light.Background
+		}
+		chart.LightTheme.BackgroundColor = ""
+		chart.DarkTheme.BackgroundColor = ""
+		chart.EnableDarkModeSupport(!theme.NoDarkMode)
+		applyThemeColors(&chart.LightTheme.BackgroundColor, &chart.LightTheme.TextColor, &chart.LightTheme.AxisColor, &chart.LightTheme.GridColor, theme.Light)
+		applyThemeColors(&chart.DarkTheme.BackgroundColor, &chart.DarkTheme.TextColor, &chart.DarkTheme.AxisColor, &chart.DarkTheme.GridColor, theme.Dark)
+	}
+
+	if theme.Spacing.MarginTop > 0 {
+		chart.Margin.Top = theme.Spacing.MarginTop
+	}
+	if theme.Spacing.MarginRight > 0 {
+		chart.Margin.Right = theme.Spacing.MarginRight
+	}
+	if theme.Spacing.MarginBottom > 0 {
+		chart.Margin.Bottom = theme.Spacing.MarginBottom
+	}
+	if theme.Spacing.MarginLeft > 0 {
+		chart.Margin.Left = theme.Spacing.MarginLeft
+	}
+	if theme.Spacing.LegendWidth > 0 {
+		chart.SetLegendWidth(theme.Spacing.LegendWidth)
+	}
+
+	// Heatmaps use their colors as intensity buckets, so a series palette does not apply
+	if chart.ChartType != "heatmap" {
+		if theme.Palette != "" {
+			chart.SetPalette(theme.Palette)
+		} else if len(theme.Colors) > 0 {
+			chart.Colors = append([]string(nil), theme.Colors...)
+			chart.SeriesColors = nil
+		}
+	}
+	chart.Patterns = theme.Patterns
+	return chart
+}
+
+// SetTypography replaces the chart's typography, zero values keep the defaults
+func (chart *BaseChart) SetTypography(typography Typography) *BaseChart {
+	chart.Typography = typography
+	return chart
+}
+
+// SetFontFamily sets the CSS font stack for all chart text, e.g. "Inter, Helvetica, sans-serif"
+func (chart *BaseChart) SetFontFamily(family string) *BaseChart {
+	chart.Typography.FontFamily = family
+	return chart
+}
+
+// SetFontSizes sets the font sizes in pixels for the title, labels, ticks and legend
+// A zero size leaves that role unchanged
+func (chart *BaseChart) SetFontSizes(title, label, tick, legend int) *BaseChart {
+	for _, size := range []struct {
+		field *int
+		value int
+	}{{&chart.Typography.TitleSize, title}, {&chart.Typography.LabelSize, label}, {&chart.Typography.TickSize, tick}, {&chart.Typography.LegendSize, legend}} {
+		if size.value > 0 {
+			*size.field = size.value
+		}
+	}
+	return chart
+}
+
+// SetFontWeights sets the CSS font weights (e.g. "bold", "600") for the title, labels, ticks and legend
+// An empty weight leaves that role unchanged
+func (chart *BaseChart) SetFontWeights(title, label, tick, legend string) *BaseChart {
+	for _, weight := range []struct {
+		field *string
+		value string
+	}{{&chart.Typography.TitleWeight, title}, {&chart.Typography.LabelWeight, label}, {&chart.Typography.TickWeight, tick}, {&chart.Typography.LegendWeight, legend}} {
+		if weight.value != "" {
+			*weight.field = weight.value
+		}
+	}
+	return chart
+}
+
+// EmbedFont embeds a WOFF2 font with @font-face and puts it first in the font stack,
+// so the SVG renders the same without the font installed
+func (chart *BaseChart) EmbedFont(family string, woff2 []byte) *BaseChart {
+	chart.Typography.FontFace = &EmbeddedFont{Family: family, WOFF2: woff2}
+	return chart
+}
+
+// applyThemeColors overwrites the given color fields with the non-empty theme colors
+func applyThemeColors(background, text, axis, grid *string, colors ThemeColors) {
+	for _, pair := range []struct {
+		field *string
+		value string
+	}{{background, colors.Background}, {text, colors.Text}, {axis, colors.Axis}, {grid, colors.Grid}} {
+		if pair.value != "" {
+			*pair.field = pair.value
+		}
+	}
+}
+
+// lineWidth returns the stroke width for data lines
+func (chart *BaseChart) lineWidth() float64 {
+	if chart.Strokes.Line > 0 {
+		return chart.Strokes.Line
+	}
+	return 3
+}
+
+// axisWidth returns the stroke width for axis lines
+func (chart *BaseChart) axisWidth() float64 {
+	if chart.Strokes.Axis > 0 {
+		return chart.Strokes.Axis
+	}
+	return 2
+}
+
+// gridWidth returns the stroke width for grid and tick lines
+func (chart *BaseChart) gridWidth() float64 {
+	if chart.Strokes.Grid > 0 {
+		return chart.Strokes.Grid
+	}
+	return 1
+}
+
+// typographyScope returns the class placed on the root svg element and the style rules for its text roles
+// The class is derived from the rules, so inlined charts with different typography do not affect each other
+func (chart *BaseChart) typographyScope() (scope, style string) {
+	t := chart.Typography
+	family := "Arial, sans-serif"
+	if t.FontFamily != "" {
+		family = cssValue(t.FontFamily)
+	}
+
+	var css strings.Builder
+	if t.FontFace != nil && t.FontFace.Family != "" && len(t.FontFace.WOFF2) > 0 {
+		face := cssValue(strings.Trim(t.FontFace.Family, `"'`))
+		css.WriteString(fmt.Sprintf(`@font-face { font-family: "%s"; src: url(data:font/woff2;base64,%s) format("woff2"); }`,
+			face, base64.StdEncoding.EncodeToString(t.FontFace.WOFF2)))
+		if !strings.Contains(family, face) {
+			family = fmt.Sprintf(`"%s", %s`, face, family)
+		}
+	}
+
+	var rules strings.Builder
+	for _, role := range []struct {
+		name   string
+		size   int
+		weight string
+		size0  int
+	}{
+		{"title", t.TitleSize, valueOr(t.TitleWeight, "bold"), 20},
+		{"label", t.LabelSize, valueOr(t.LabelWeight, "normal"), 12},
+		{"tick", t.TickSize, valueOr(t.TickWeight, "normal"), 10},
+		{"legend", t.LegendSize, valueOr(t.LegendWeight, "normal"), 12},
+	} {
+		size := role.size
+		if size <= 0 {
+			size = role.size0
+		}
+		rules.WriteString(fmt.Sprintf(" .chart-%s { font-family: %s; font-size: %dpx; font-weight: %s; }",
+			role.name, family, size, cssValue(role.weight)))
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(css.String() + rules.String()))
+	scope = fmt.Sprintf("gosvgchart-%08x", h.Sum32())
+	css.WriteString(strings.ReplaceAll(rules.String(), " .chart-", "."+scope+" .chart-"))
+	return scope, "<style>" + css.String() + "</style>"
+}
+
+// valueOr returns value, or fallback when value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// cssValue strips characters that could end a CSS declaration or the style element
+// Style content is not entity decoded when the SVG is inlined in HTML, so escaping is not an option
+func cssValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune("<>{};", r) {
+			return -1
+		}
+		return r
+	}, value)
+}
@@ -0,0 +1,141 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SetTooltip sets the tooltip of every bar, point, slice, heatmap cell, flow and task from a
+// template such as "{series}: {value:si} on {label}"; an empty template leaves bars, points
+// and slices without tooltips and keeps the built-in ones of the other chart types
+// {value} and {percent} (the share of a pie slice) take a format after a colon: "si" for
+// k, M and G suffixes, or a fmt verb such as ".1f"
+// Sankey flows also fill in {source} and {target}, and Gantt tasks {task}, {start}, {end} and {progress}
+func (chart *BaseChart) SetTooltip(template string) *BaseChart {
+	chart.Tooltip = template
+	return chart
+}
+
+// SetHoverHighlight dims the other marks while one is hovered, with CSS only, so it works
+// where scripts are stripped
+func (chart *BaseChart) SetHoverHighlight(enabled bool) *BaseChart {
+	chart.HoverHighlight = enabled
+	return chart
+}
+
+// tooltipText returns the tooltip of a mark from the template, or the series name for a mark
+// that stands for a whole series such as a line
+func (chart *BaseChart) tooltipText(fields markFields) string {
+	if chart.Tooltip == "" {
+		return ""
+	}
+	if IsMissing(fields.value) {
+		return fields.series
+	}
+	return fields.fill(chart.Tooltip, nil)
+}
+
+// cellTooltip returns the tooltip of a heatmap cell, flow or task: the template filled in
+// when one is set, or the built-in text
+func (chart *BaseChart) cellTooltip(fields markFields, text string) string {
+	if chart.Tooltip != "" {
+		return fields.fill(chart.Tooltip, nil)
+	}
+	return text
+}
+
+// fill replaces the {series}, {label}, {value} and {percent} placeholders of a template,
+// passing each through escape when it is set; unknown placeholders are kept as they are
+func (fields markFields) fill(template string, escape func(string) string) string {
+	var text strings.Builder
+	for {
+		start := strings.Index(template, "{")
+		if start < 0 {
+			break
+		}
+		length := strings.Index(template[start:], "}")
+		if length < 0 {
+			break
+		}
+		end := start + length
+		text.WriteString(template[:start])
+
+		name, spec, _ := strings.Cut(template[start+1:end], ":")
+		var field string
+		switch name {
+		case "series":
+			field = fields.series
+		case "label":
+			field = fields.label
+		case "value":
+			field = formatTemplateValue(fields.value, spec)
+		case "percent":
+			field = formatTemplateValue(fields.percent, spec)
+		default:
+			known := false
+			for _, extra := range fields.extra {
+				if extra[0] == name {
+					field, known = extra[1], true
+				}
+			}
+			if !known {
+				text.WriteString(template[start : end+1])
+				template = template[end+1:]
+				continue
+			}
+		}
+		if escape != nil {
+			field = escape(field)
+		}
+		text.WriteString(field)
+		template = template[end+1:]
+	}
+	text.WriteString(template)
+	return text.String()
+}
+
+// formatTemplateValue formats a value for a template: "si" abbreviates with SI suffixes, a fmt
+// verb such as ".1f" is applied as is, and no format gives the value in full precision
+func formatTemplateValue(value float64, spec string) string {
+	switch {
+	case IsMissing(value):
+		return ""
+	case spec == "si":
+		return formatSI(value)
+	case spec != "" && strings.ContainsAny(spec[len(spec)-1:], "eEfFgG"):
+		return fmt.Sprintf("%"+spec, value)
+	}
+	return formatDataValue(value)
+}
+
+// formatSI abbreviates a value to three significant digits with a k, M, G, T or P suffix
+func formatSI(value float64) string {
+	suffixes := []string{"", "k", "M", "G", "T", "P"}
+	k := 0
+	for math.Abs(value) >= 999.5 && k < len(suffixes)-1 {
+		value /= 1000
+		k++
+	}
+	return strconv.FormatFloat(value, 'g', 3, 64) + suffixes[k]
+}
+
+// markClass returns the class attribute that marks an element for hover highlighting
+// and for the interaction script
+func (chart *BaseChart) markClass() string {
+	if chart.HoverHighlight || chart.Interactive {
+		return ` class="chart-mark"`
+	}
+	return ""
+}
+
+// hoverStyle returns the CSS that dims the other marks of the chart while one is hovered
+func (chart *BaseChart) hoverStyle(scope string) string {
+	if !chart.HoverHighlight {
+		return ""
+	}
+	return fmt.Sprintf(`<style>.%[1]s .chart-mark { transition: opacity 0.15s; } `+
+		`.%[1]s:has(.chart-mark:hover) .chart-mark:not(:hover) { opacity: 0.3; } `+
+		`.%[1]s .chart-mark:hover { filter: brightness(1.1); }</style>`, scope)
+}
@@ -0,0 +1,267 @@
+package gosvgchart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// X-axis label strategies for line and bar charts
+const (
+	XLabelsAuto       = "auto"       // Try horizontal, wrapped, 45°, 90° and finally every Nth label
+	XLabelsHorizontal = "horizontal" // Always draw every label horizontally, even when they overlap
+	XLabelsWrap       = "wrap"       // Wrap labels on spaces
+	XLabelsRotate45   = "rotate45"   // Rotate labels by 45°
+	XLabelsRotate90   = "rotate90"   // Rotate labels by 90°
+	XLabelsSkip       = "skip"       // Draw every Nth label horizontally
+)
+
+// xLabelGap is the minimum space in pixels between two x-axis labels
+const xLabelGap = 4
+
+// xLabelLayout describes how the category labels below the plot area are drawn
+type xLabelLayout struct {
+	angle  int        // Rotation in degrees: 0, 45 or 90
+	lines  [][]string // Lines of each label, more than one when wrapped
+	step   int        // Draw every step-th label
+	height int        // Space needed below the x-axis
+}
+
+// SetXLabelStrategy forces how line and bar charts fit their x-axis labels:
+// "auto" (default), "horizontal", "wrap", "rotate45", "rotate90" or "skip"
+func (chart *BaseChart) SetXLabelStrategy(strategy string) *BaseChart {
+	chart.XLabelStrategy = strings.ToLower(strings.TrimSpace(strategy))
+	return chart
+}
+
+// SetXLabelStep draws only every step-th x-axis label, whatever the strategy
+// Zero lets the chart choose the step when labels still overlap
+func (chart *BaseChart) SetXLabelStep(step int) *BaseChart {
+	chart.XLabelStep = step
+	return chart
+}
+
+// categoryCount returns the number of x positions: the length of the longest series,
+// or of the legacy data
+func (chart *BaseChart) categoryCount() int {
+	if len(chart.Series) == 0 {
+		return len(chart.Data)
+	}
+	count := 0
+	for _, series := range chart.Series {
+		if len(series.Data) > count {
+			count = len(series.Data)
+		}
+	}
+	return count
+}
+
+// layoutXLabels picks how to draw the x-axis labels when neighboring labels are slot pixels apart
+func (chart *BaseChart) layoutXLabels(slot float64) xLabelLayout {
+	labels := chart.xAxisLabels()
+	if count := chart.categoryCount(); len(labels) > count {
+		labels = labels[:count]
+	}
+	single := make([][]string, len(labels))
+	for i, label := range labels {
+		single[i] = []string{label}
+	}
+	lineHeight := chart.fontSize("label") * 1.2
+
+	horizontal := xLabelLayout{lines: single, step: 1}
+	wrapped := xLabelLayout{lines: chart.wrapXLabels(labels, slot-xLabelGap), step: 1}
+	rotated45 := xLabelLayout{angle: 45, lines: single, step: 1}
+	rotated90 := xLabelLayout{angle: 90, lines: single, step: 1}
+
+	var layout xLabelLayout
+	switch chart.XLabelStrategy {
+	case XLabelsHorizontal:
+		layout = horizontal
+	case XLabelsWrap:
+		layout = wrapped
+	case XLabelsRotate45:
+		layout = rotated45
+	case XLabelsRotate90:
+		layout = rotated90
+	case XLabelsSkip:
+		layout = horizontal
+		layout.step = chart.xLabelStep(horizontal, slot)
+	default:
+		// Each strategy is only used when its labels don't collide
+		switch {
+		case chart.xLabelStep(horizontal, slot) == 1:
+			layout = horizontal
+		case len(wrapped.lines) > 0 && chart.xLabelStep(wrapped, slot) == 1:
+			layout = wrapped
+		case slot*math.Sin(math.Pi/4) >= lineHeight:
+			layout = rotated45
+		default:
+			layout = rotated90
+			layout.step = chart.xLabelStep(rotated90, slot)
+		}
+	}
+	if layout.lines == nil {
+		// Labels can't be wrapped, e.g. a word is wider than its slot
+		layout.lines = single
+	}
+	if chart.XLabelStep > 0 {
+		layout.step = chart.XLabelStep
+	}
+
+	// Rotated labels may take at most 40% of the chart height
+	if layout.angle > 0 {
+		maxLength := float64(chart.Height) * 0.4 / math.Sin(float64(layout.angle)*math.Pi/180)
+		longest := 0.0
+		for _, label := range labels {
+			longest = math.Max(longest, math.Min(chart.textWidth(label, "label"), maxLength))
+		}
+		radians := float64(layout.angle) * math.Pi / 180
+		layout.height = int(math.Ceil(10 + longest*math.Sin(radians) + lineHeight*math.Cos(radians)))
+	} else {
+		maxLines := 1
+		for _, lines := range layout.lines {
+			maxLines = max(maxLines, len(lines))
+		}
+		layout.height = int(math.Ceil(25 + float64(maxLines-1)*lineHeight))
+	}
+	return layout
+}
+
+// xLabelStep returns the smallest step at which horizontal labels in a layout don't overlap
+// A 90° layout only needs room for the height of a line
+func (chart *BaseChart) xLabelStep(layout xLabelLayout, slot float64) int {
+	if slot <= 0 {
+		return 1
+	}
+	widest := 0.0
+	if layout.angle == 90 {
+		widest = chart.fontSize("label") * 1.2
+	} else {
+		for _, lines := range layout.lines {
+			for _, line := range lines {
+				widest = math.Max(widest, chart.textWidth(line, "label"))
+			}
+		}
+	}
+	return max(1, int(math.Ceil((widest+xLabelGap)/slot)))
+}
+
+// wrapXLabels breaks labels on spaces into lines no wider than maxWidth
+// It returns nil when a label has a word that doesn't fit or needs more than three lines
+func (chart *BaseChart) wrapXLabels(labels []string, maxWidth float64) [][]string {
+	wrapped := make([][]string, len(labels))
+	for i, label := range labels {
+		var lines []string
+		for _, word := range strings.Fields(label) {
+			if chart.textWidth(word, "label") > maxWidth {
+				return nil
+			}
+			if n := len(lines); n > 0 && chart.textWidth(lines[n-1]+" "+word, "label") <= maxWidth {
+				lines[n-1] += " " + word
+			} else {
+				lines = append(lines, word)
+			}
+		}
+		if len(lines) > 3 {
+			return nil
+		}
+		if len(lines) == 0 {
+			lines = []string{label}
+		}
+		wrapped[i] = lines
+	}
+	return wrapped
+}
+
+// reserveXLabelSpace grows the bottom margin so the x-axis labels and title fit below the plot area
+// The returned function restores the configured margin once rendering is done
+func (chart *BaseChart) reserveXLabelSpace(layout xLabelLayout) func() {
+	bottom := chart.Margin.Bottom
+	needed := layout.height
+	if chart.XAxisTitle != "" {
+		needed += chart.axisTitleHeight()
+	}
+	if needed > chart.Margin.Bottom {
+		chart.Margin.Bottom = needed
+	}
+	return func() { chart.Margin.Bottom = bottom }
+}
+
+// reserveEdgeLabelSpace grows the left and, unless a legend area takes that side, the right
+// margin so the measured x-axis labels at the ends of a line chart's axis, centered on them,
+// fit within the chart; no margin grows by more than a quarter of the chart width
+// The returned function restores the configured margins once rendering is done
+func (chart *BaseChart) reserveEdgeLabelSpace(hasLegendArea bool) func() {
+	left, right := chart.Margin.Left, chart.Margin.Right
+	labels := chart.xAxisLabels()
+	if count := chart.categoryCount(); len(labels) > count {
+		labels = labels[:count]
+	}
+	if len(labels) < 2 {
+		return func() {}
+	}
+	limit := float64(chart.Width) / 4
+	if needed := int(math.Ceil(math.Min(chart.textWidth(labels[0], "label")/2, limit))); needed > chart.Margin.Left {
+		chart.Margin.Left = needed
+	}
+	if needed := int(math.Ceil(math.Min(chart.textWidth(labels[len(labels)-1], "label")/2, limit))); needed > chart.Margin.Right && !hasLegendArea {
+		chart.Margin.Right = needed
+	}
+	return func() { chart.Margin.Left, chart.Margin.Right = left, right }
+}
+
+// renderXLabels draws the category labels below the plot area at the given x positions
+func (chart *BaseChart) renderXLabels(svg *strings.Builder, positions []int, layout xLabelLayout) {
+	axisY := chart.Height - chart.Margin.Bottom
+	lineHeight := chart.fontSize("label") * 1.2
+	maxLength := 0.0
+	if layout.angle > 0 {
+		maxLength = float64(chart.Height) * 0.4 / math.Sin(float64(layout.angle)*math.Pi/180)
+	}
+
+	// Interactive line charts move the labels with the X zoom
+	if chart.Interactive {
+		svg.WriteString(`<g class="chart-xlabels">`)
+		defer svg.WriteString(`</g>`)
+	}
+
+	labels := chart.xAxisLabels()
+	for i, x := range positions {
+		if i >= len(labels) || i >= len(layout.lines) {
+			break
+		}
+		if layout.step > 1 && i%layout.step != 0 {
+			continue
+		}
+		label := labels[i]
+
+		if layout.angle > 0 {
+			// Rotated labels end just below their tick and run down to the left
+			display := chart.truncateText(label, "label", maxLength)
+			tooltip := ""
+			if display != label {
+				tooltip = "<title>" + escapeText(label) + "</title>"
+			}
+			y := axisY + 12
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="end" transform="rotate(-%d %d %d)" class="chart-label"%s>%s%s</text>`,
+				x, y, layout.angle, x, y, chart.textFill(), escapeText(display), tooltip))
+			continue
+		}
+
+		lines := layout.lines[i]
+		if len(lines) == 1 {
+			svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label"%s>%s</text>`,
+				x, axisY+20, chart.textFill(), escapeText(lines[0])))
+			continue
+		}
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle" class="chart-label"%s>`, x, axisY+20, chart.textFill()))
+		for j, line := range lines {
+			dy := "0"
+			if j > 0 {
+				dy = fmt.Sprintf("%g", lineHeight)
+			}
+			svg.WriteString(fmt.Sprintf(`<tspan x="%d" dy="%s">%s</tspan>`, x, dy, escapeText(line)))
+		}
+		svg.WriteString(`</text>`)
+	}
+}